@@ -0,0 +1,327 @@
+package submail
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+	"time"
+)
+
+// BulkRecipient 批量任务的收件人
+type BulkRecipient struct {
+	To   string            // 手机号码
+	Vars map[string]string // 变量
+}
+
+// BulkResult 单个收件人的发送结果
+type BulkResult struct {
+	To      string // 手机号码
+	SendID  string // 发送ID
+	Fee     int    // 计费条数
+	Skipped bool   // 收件人在屏蔽名单中，未发送（Err 为 *SuppressedError）
+	Err     error  // 发送失败或跳过的原因
+}
+
+// BulkProgress 批量任务进度
+type BulkProgress struct {
+	Sent    int           // 发送成功数
+	Failed  int           // 发送失败数
+	Skipped int           // 屏蔽名单跳过数
+	Fee     int           // 已计费条数
+	Elapsed time.Duration // 已运行时间（不含暂停时间）
+}
+
+// Done 已处理的收件人数
+func (p BulkProgress) Done() int {
+	return p.Sent + p.Failed + p.Skipped
+}
+
+// BulkReport 批量任务报告
+type BulkReport struct {
+	Success  int           // 发送成功数
+	Failed   int           // 发送失败数
+	Skipped  int           // 屏蔽名单跳过数（不计入失败）
+	TotalFee int           // 总计费条数
+	Failures []BulkResult  // 失败的收件人（按完成顺序）
+	Duration time.Duration // 运行时间（不含暂停时间）
+}
+
+// GetStatistics 获取发送统计信息（与 SMSMultiSendResponse.GetStatistics 一致）
+func (r *BulkReport) GetStatistics() (success, failed, totalFee int) {
+	return r.Success, r.Failed, r.TotalFee
+}
+
+// BulkConfig 批量任务配置
+type BulkConfig struct {
+	Project   string // 短信模板ID，设置后使用 SMSXSend 发送
+	Signature string // 模板发送的自定义短信签名（可选）
+	Content   string // 短信正文（支持@var(key)和@date()变量），未设置 Project 时使用 SMSSend 发送
+	Tag       string // 自定义标签
+
+	// Workers 并发发送的协程数（默认10），客户端配置的限流（RateLimiter）同样生效
+	Workers int
+
+	// OnProgress 每个收件人处理完成后回调（回调按顺序串行执行，应尽快返回）
+	OnProgress func(progress BulkProgress)
+
+	// OnResult 每个收件人的发送结果回调（与 OnProgress 在同一协程中串行执行）
+	OnResult func(result BulkResult)
+}
+
+// BulkSender 批量发送任务：使用固定大小的协程池逐个发送，支持暂停、恢复与通过 context 取消
+//
+//	bulk := submail.NewBulkSender(client, submail.BulkConfig{Project: "abc123", Workers: 20})
+//	report, err := bulk.Run(ctx, recipients) // recipients 为 <-chan BulkRecipient
+type BulkSender struct {
+	sender SMSSender
+	config BulkConfig
+	locale string
+
+	mu       sync.Mutex
+	gate     chan struct{} // 运行时为已关闭的 channel，暂停时为未关闭的 channel
+	progress BulkProgress
+	started  time.Time     // 本次运行（或恢复）的开始时间
+	elapsed  time.Duration // 暂停前累计的运行时间
+	report   *BulkReport
+
+	callbackMu sync.Mutex
+}
+
+// NewBulkSender 创建批量发送任务，sender 通常为 *Client
+func NewBulkSender(sender SMSSender, config BulkConfig) *BulkSender {
+	if config.Workers <= 0 {
+		config.Workers = 10
+	}
+	locale := DefaultLocale()
+	if client, ok := sender.(*Client); ok {
+		locale = client.Locale()
+	}
+	gate := make(chan struct{})
+	close(gate)
+	return &BulkSender{sender: sender, config: config, locale: locale, gate: gate}
+}
+
+// Run 发送 channel 中的所有收件人，channel 关闭且全部发送完成后返回报告
+// ctx 取消时停止发送（已开始的请求会随 ctx 一同取消），返回截至当时的报告与 ctx 的错误
+func (b *BulkSender) Run(ctx context.Context, recipients <-chan BulkRecipient) (*BulkReport, error) {
+	return b.RunSeq(ctx, func(yield func(BulkRecipient) bool) {
+		for {
+			select {
+			case recipient, ok := <-recipients:
+				if !ok || !yield(recipient) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// RunSlice 发送切片中的所有收件人
+func (b *BulkSender) RunSlice(ctx context.Context, recipients []BulkRecipient) (*BulkReport, error) {
+	return b.RunSeq(ctx, func(yield func(BulkRecipient) bool) {
+		for _, recipient := range recipients {
+			if !yield(recipient) {
+				return
+			}
+		}
+	})
+}
+
+// RunSeq 发送迭代器中的所有收件人
+func (b *BulkSender) RunSeq(ctx context.Context, recipients iter.Seq[BulkRecipient]) (*BulkReport, error) {
+	b.mu.Lock()
+	b.progress = BulkProgress{}
+	b.report = &BulkReport{}
+	b.elapsed = 0
+	b.started = time.Now()
+	b.mu.Unlock()
+
+	jobs := make(chan BulkRecipient)
+	var wg sync.WaitGroup
+	for i := 0; i < b.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for recipient := range jobs {
+				b.record(b.send(ctx, recipient))
+			}
+		}()
+	}
+
+dispatch:
+	for recipient := range recipients {
+		if !b.wait(ctx) {
+			break
+		}
+		select {
+		case jobs <- recipient:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	report := b.report
+	report.Duration = b.elapsedLocked()
+	return report, ctx.Err()
+}
+
+// Pause 暂停发送：正在进行的请求完成后不再发送新的收件人，直到调用 Resume
+func (b *BulkSender) Pause() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pausedLocked() {
+		return
+	}
+	b.elapsed = b.elapsedLocked()
+	b.gate = make(chan struct{})
+}
+
+// Resume 恢复发送
+func (b *BulkSender) Resume() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.pausedLocked() {
+		return
+	}
+	b.started = time.Now()
+	close(b.gate)
+}
+
+// Paused 是否处于暂停状态
+func (b *BulkSender) Paused() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pausedLocked()
+}
+
+// Progress 获取当前进度
+func (b *BulkSender) Progress() BulkProgress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	progress := b.progress
+	progress.Elapsed = b.elapsedLocked()
+	return progress
+}
+
+// pausedLocked 是否处于暂停状态（调用方需持有锁）
+func (b *BulkSender) pausedLocked() bool {
+	select {
+	case <-b.gate:
+		return false
+	default:
+		return true
+	}
+}
+
+// elapsedLocked 累计运行时间（调用方需持有锁）
+func (b *BulkSender) elapsedLocked() time.Duration {
+	if b.started.IsZero() || b.pausedLocked() {
+		return b.elapsed
+	}
+	return b.elapsed + time.Since(b.started)
+}
+
+// wait 暂停时阻塞直到恢复，ctx 取消时返回false
+func (b *BulkSender) wait(ctx context.Context) bool {
+	b.mu.Lock()
+	gate := b.gate
+	b.mu.Unlock()
+
+	select {
+	case <-gate:
+		return ctx.Err() == nil
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// send 发送给单个收件人
+func (b *BulkSender) send(ctx context.Context, recipient BulkRecipient) BulkResult {
+	result := BulkResult{To: recipient.To}
+
+	var (
+		resp     *SMSSendResponse
+		err      error
+		endpoint = EndpointSMSSend
+	)
+	if b.config.Project != "" {
+		endpoint = EndpointSMSXSend
+		resp, err = b.sender.SMSXSendCtx(ctx, &SMSXSendRequest{
+			To:           recipient.To,
+			Project:      b.config.Project,
+			Vars:         recipient.Vars,
+			SMSSignature: b.config.Signature,
+			Tag:          b.config.Tag,
+		})
+	} else {
+		resp, err = b.sender.SMSSendCtx(ctx, &SMSSendRequest{
+			To:      recipient.To,
+			Content: b.render(recipient.Vars),
+			Tag:     b.config.Tag,
+		})
+	}
+
+	switch {
+	case errors.Is(err, ErrSuppressed):
+		result.Skipped = true
+		result.Err = err
+	case err != nil:
+		result.Err = err
+	case resp == nil:
+		result.Err = localizedError(b.locale, msgNoResponse, endpoint)
+	case resp.Status != "success":
+		result.Err = NewAPIErrorWithLocale(b.locale, resp.Code, resp.Msg)
+	default:
+		result.SendID = resp.SendID
+		result.Fee = resp.Fee
+	}
+	return result
+}
+
+// render 替换正文中的变量（sender 为 *Client 时使用客户端的变量处理器）
+func (b *BulkSender) render(vars map[string]string) string {
+	if client, ok := b.sender.(*Client); ok {
+		return client.ProcessVariables(b.config.Content, vars)
+	}
+	return NewVariableProcessor().ProcessVariables(b.config.Content, vars)
+}
+
+// record 记录发送结果并触发回调
+func (b *BulkSender) record(result BulkResult) {
+	b.mu.Lock()
+	switch {
+	case result.Skipped:
+		b.progress.Skipped++
+		b.report.Skipped++
+	case result.Err != nil:
+		b.progress.Failed++
+		b.report.Failed++
+		b.report.Failures = append(b.report.Failures, result)
+	default:
+		b.progress.Sent++
+		b.progress.Fee += result.Fee
+		b.report.Success++
+		b.report.TotalFee += result.Fee
+	}
+	progress := b.progress
+	progress.Elapsed = b.elapsedLocked()
+
+	// 在释放状态锁之前获取回调锁，保证回调顺序与进度一致
+	b.callbackMu.Lock()
+	b.mu.Unlock()
+	defer b.callbackMu.Unlock()
+
+	if b.config.OnResult != nil {
+		b.config.OnResult(result)
+	}
+	if b.config.OnProgress != nil {
+		b.config.OnProgress(progress)
+	}
+}
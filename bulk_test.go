@@ -0,0 +1,122 @@
+package submail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestBulkSenderReport(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	suppression := submail.NewSuppressionList(submail.NewMemorySuppressionStore())
+	suppression.Add(context.Background(), "13800138002", submail.SuppressionManual, "")
+	config := server.Config()
+	config.Suppression = suppression
+	client := submail.NewClient(config)
+
+	server.FailPhone("13800138001", submail.ErrPhoneFrequencyLimit)
+
+	var progress []submail.BulkProgress
+	bulk := submail.NewBulkSender(client, submail.BulkConfig{
+		Content:    "【测试】@var(name)您好",
+		Workers:    2,
+		OnProgress: func(p submail.BulkProgress) { progress = append(progress, p) },
+	})
+	report, err := bulk.RunSlice(context.Background(), []submail.BulkRecipient{
+		{To: "13800138000", Vars: map[string]string{"name": "张三"}},
+		{To: "13800138001", Vars: map[string]string{"name": "李四"}},
+		{To: "13800138002", Vars: map[string]string{"name": "王五"}},
+		{To: "13800138003", Vars: map[string]string{"name": "赵六"}},
+	})
+	if err != nil {
+		t.Fatalf("RunSlice: %v", err)
+	}
+
+	if report.Success != 2 || report.Failed != 1 || report.Skipped != 1 || report.TotalFee != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].To != "13800138001" {
+		t.Fatalf("failures = %+v", report.Failures)
+	}
+	for i, p := range progress {
+		if p.Done() != i+1 {
+			t.Fatalf("progress %d = %+v, want %d done", i, p, i+1)
+		}
+	}
+	if msgs := server.MessagesTo("13800138000"); len(msgs) != 1 || msgs[0].Content != "【测试】张三您好" {
+		t.Fatalf("message = %+v", msgs)
+	}
+	if n := len(server.MessagesTo("13800138002")); n != 0 {
+		t.Fatalf("suppressed number received %d messages", n)
+	}
+}
+
+func TestBulkSenderTemplateAndNilResponse(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	recorder.ReturnNext("SMSXSend", (*submail.SMSSendResponse)(nil), nil)
+	bulk := submail.NewBulkSender(recorder, submail.BulkConfig{Project: "abc123", Signature: "【测试】", Workers: 1})
+
+	report, err := bulk.RunSlice(context.Background(), []submail.BulkRecipient{
+		{To: "13800138000", Vars: map[string]string{"code": "1234"}},
+		{To: "13800138001", Vars: map[string]string{"code": "5678"}},
+	})
+	if err != nil {
+		t.Fatalf("RunSlice: %v", err)
+	}
+	if report.Failed != 1 || report.Success != 1 {
+		t.Fatalf("report = %+v, want one nil-response failure and one success", report)
+	}
+
+	calls := recorder.CallsOf("SMSXSend")
+	if len(calls) != 2 {
+		t.Fatalf("got %d SMSXSend calls, want 2", len(calls))
+	}
+	req := calls[1].Request.(*submail.SMSXSendRequest)
+	if req.Project != "abc123" || req.SMSSignature != "【测试】" || req.Vars["code"] != "5678" {
+		t.Fatalf("request = %+v", req)
+	}
+}
+
+func TestBulkSenderPauseAndCancel(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	bulk := submail.NewBulkSender(submail.NewClient(server.Config()), submail.BulkConfig{Content: "【测试】您好", Workers: 1})
+
+	bulk.Pause()
+	if !bulk.Paused() {
+		t.Fatal("Paused() = false after Pause")
+	}
+
+	recipients := make(chan submail.BulkRecipient, 2)
+	recipients <- submail.BulkRecipient{To: "13800138000"}
+	recipients <- submail.BulkRecipient{To: "13800138001"}
+	close(recipients)
+
+	done := make(chan *submail.BulkReport)
+	go func() {
+		report, _ := bulk.Run(context.Background(), recipients)
+		done <- report
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if n := len(server.Messages()); n != 0 {
+		t.Fatalf("paused sender sent %d messages", n)
+	}
+	bulk.Resume()
+	if report := <-done; report.Success != 2 {
+		t.Fatalf("report = %+v, want 2 sent after Resume", report)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := bulk.RunSlice(ctx, []submail.BulkRecipient{{To: "13800138002"}})
+	if !errors.Is(err, context.Canceled) || report.Success+report.Failed+report.Skipped != 0 {
+		t.Fatalf("report = %+v, err = %v, want nothing sent and context.Canceled", report, err)
+	}
+}
@@ -0,0 +1,296 @@
+package submail
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// 每次请求的收件人数上限
+const (
+	MaxMultiRecipients = 200   // 一对多发送（SMSMultiSend、SMSMultiXSend）
+	MaxBatchRecipients = 10000 // 批量群发（SMSBatchSend、SMSBatchXSend）
+)
+
+// ChunkOptions 分批发送选项
+type ChunkOptions struct {
+	ChunkSize   int // 每批收件人数（默认且最大为接口上限）
+	Concurrency int // 并发请求数（默认1，按顺序发送）
+}
+
+// ChunkError 单个批次发送失败
+type ChunkError struct {
+	Index  int   // 批次序号（从0开始）
+	Offset int   // 批次第一个收件人在输入列表中的位置
+	Size   int   // 批次收件人数
+	Err    error // 失败原因
+
+	locale string
+}
+
+func (e *ChunkError) Error() string {
+	return localizef(e.locale, msgChunkFailed, e.Index+1, e.Offset+1, e.Offset+e.Size, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// ChunkedError 分批发送时部分批次失败（其他批次的结果仍然有效）
+// 支持 errors.Is / errors.As 判断各批次的错误
+type ChunkedError struct {
+	Chunks []*ChunkError // 失败的批次（按批次顺序）
+	Total  int           // 批次总数
+
+	locale string
+}
+
+func (e *ChunkedError) Error() string {
+	return localizef(e.locale, msgChunksFailed, len(e.Chunks), e.Total, e.Chunks[0])
+}
+
+func (e *ChunkedError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, chunk := range e.Chunks {
+		errs[i] = chunk
+	}
+	return errs
+}
+
+// chunk 一个批次的收件人范围 [start, end)
+type chunk struct {
+	index, start, end int
+}
+
+// planChunks 按批次大小拆分收件人，批次大小默认且最大为 limit
+func planChunks(total, limit int, opts *ChunkOptions) []chunk {
+	size := limit
+	if opts != nil && opts.ChunkSize > 0 && opts.ChunkSize < limit {
+		size = opts.ChunkSize
+	}
+
+	var chunks []chunk
+	for start := 0; start < total; start += size {
+		end := start + size
+		if end > total {
+			end = total
+		}
+		chunks = append(chunks, chunk{index: len(chunks), start: start, end: end})
+	}
+	return chunks
+}
+
+// runChunks 执行各批次的发送，opts.Concurrency 限制同时进行的请求数，返回失败的批次
+// ctx 取消后尚未开始的批次不再发送，并以 ctx 的错误记为失败
+func (c *Client) runChunks(ctx context.Context, chunks []chunk, opts *ChunkOptions, send func(ctx context.Context, ch chunk) error) *ChunkedError {
+	concurrency := 1
+	if opts != nil && opts.Concurrency > 1 {
+		concurrency = opts.Concurrency
+	}
+
+	errs := make([]*ChunkError, len(chunks))
+	fail := func(ch chunk, err error) {
+		errs[ch.index] = &ChunkError{Index: ch.index, Offset: ch.start, Size: ch.end - ch.start, Err: err, locale: c.locale}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, ch := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			fail(ch, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(ch chunk) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := send(ctx, ch); err != nil {
+				fail(ch, err)
+			}
+		}(ch)
+	}
+	wg.Wait()
+
+	var failed []*ChunkError
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &ChunkedError{Chunks: failed, Total: len(chunks), locale: c.locale}
+}
+
+// mergeChunks 按输入顺序合并各批次的发送结果，失败批次的收件人以 status 为 error 的结果占位
+func mergeChunks(chunks []chunk, results [][]SMSSendResult, recipients []string, chunkErr *ChunkedError) []SMSSendResult {
+	failed := make(map[int]error)
+	if chunkErr != nil {
+		for _, e := range chunkErr.Chunks {
+			failed[e.Index] = e.Err
+		}
+	}
+
+	merged := make([]SMSSendResult, 0, len(recipients))
+	for _, ch := range chunks {
+		if err, ok := failed[ch.index]; ok {
+			merged = append(merged, failedResults(recipients[ch.start:ch.end], err)...)
+			continue
+		}
+		merged = append(merged, results[ch.index]...)
+	}
+	return merged
+}
+
+// failedResults 为发送失败的批次生成逐个收件人的失败结果
+func failedResults(recipients []string, err error) []SMSSendResult {
+	result := SMSSendResult{Status: "error", Msg: err.Error()}
+	if apiErr, ok := AsAPIError(err); ok {
+		result.Code = apiErr.Code
+		result.Msg = apiErr.Msg
+	}
+
+	results := make([]SMSSendResult, len(recipients))
+	for i, to := range recipients {
+		results[i] = result
+		results[i].To = to
+	}
+	return results
+}
+
+// ===== 一对多分批发送 =====
+
+// SMSMultiSendChunked 一对多分批发送，收件人超过接口上限时自动拆分为多次请求
+func (c *Client) SMSMultiSendChunked(req *SMSMultiSendRequest, opts *ChunkOptions) (*SMSMultiSendResponse, error) {
+	return c.SMSMultiSendChunkedCtx(context.Background(), req, opts)
+}
+
+// SMSMultiSendChunkedCtx 一对多分批发送（支持 context 取消与超时控制）
+// 返回的结果与 req.Multi 顺序一致，部分批次失败时同时返回结果与 *ChunkedError
+func (c *Client) SMSMultiSendChunkedCtx(ctx context.Context, req *SMSMultiSendRequest, opts *ChunkOptions) (*SMSMultiSendResponse, error) {
+	if req == nil {
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	chunks := planChunks(len(req.Multi), MaxMultiRecipients, opts)
+	results := make([][]SMSSendResult, len(chunks))
+	chunkErr := c.runChunks(ctx, chunks, opts, func(ctx context.Context, ch chunk) error {
+		chunkReq := *req
+		chunkReq.Multi = req.Multi[ch.start:ch.end]
+		resp, err := c.SMSMultiSendCtx(ctx, &chunkReq)
+		if err != nil {
+			return err
+		}
+		results[ch.index] = *resp
+		return nil
+	})
+
+	merged := SMSMultiSendResponse(mergeChunks(chunks, results, multiRecipients(req.Multi), chunkErr))
+	if chunkErr != nil {
+		return &merged, chunkErr
+	}
+	return &merged, nil
+}
+
+// SMSMultiXSendChunked 模板一对多分批发送，收件人超过接口上限时自动拆分为多次请求
+func (c *Client) SMSMultiXSendChunked(req *SMSMultiXSendRequest, opts *ChunkOptions) (*SMSMultiSendResponse, error) {
+	return c.SMSMultiXSendChunkedCtx(context.Background(), req, opts)
+}
+
+// SMSMultiXSendChunkedCtx 模板一对多分批发送（支持 context 取消与超时控制）
+// 返回的结果与 req.Multi 顺序一致，部分批次失败时同时返回结果与 *ChunkedError
+func (c *Client) SMSMultiXSendChunkedCtx(ctx context.Context, req *SMSMultiXSendRequest, opts *ChunkOptions) (*SMSMultiSendResponse, error) {
+	if req == nil {
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	chunks := planChunks(len(req.Multi), MaxMultiRecipients, opts)
+	results := make([][]SMSSendResult, len(chunks))
+	chunkErr := c.runChunks(ctx, chunks, opts, func(ctx context.Context, ch chunk) error {
+		chunkReq := *req
+		chunkReq.Multi = req.Multi[ch.start:ch.end]
+		resp, err := c.SMSMultiXSendCtx(ctx, &chunkReq)
+		if err != nil {
+			return err
+		}
+		results[ch.index] = *resp
+		return nil
+	})
+
+	merged := SMSMultiSendResponse(mergeChunks(chunks, results, multiXRecipients(req.Multi), chunkErr))
+	if chunkErr != nil {
+		return &merged, chunkErr
+	}
+	return &merged, nil
+}
+
+// ===== 批量群发分批发送 =====
+
+// SMSBatchSendWithPhonesChunked 批量分批群发，号码超过接口上限时自动拆分为多次请求
+func (c *Client) SMSBatchSendWithPhonesChunked(content string, phones []string, tag string, opts *ChunkOptions) (*SMSBatchSendResponse, error) {
+	return c.SMSBatchSendWithPhonesChunkedCtx(context.Background(), content, phones, tag, opts)
+}
+
+// SMSBatchSendWithPhonesChunkedCtx 批量分批群发（支持 context 取消与超时控制）
+// 合并后的 BatchList 为各批次任务ID（逗号分隔），Responses 与 phones 顺序一致，
+// 部分批次失败时同时返回结果与 *ChunkedError
+func (c *Client) SMSBatchSendWithPhonesChunkedCtx(ctx context.Context, content string, phones []string, tag string, opts *ChunkOptions) (*SMSBatchSendResponse, error) {
+	return c.batchChunked(ctx, phones, opts, func(ctx context.Context, chunkPhones []string) (*SMSBatchSendResponse, error) {
+		return c.SMSBatchSendWithPhonesCtx(ctx, content, chunkPhones, tag)
+	})
+}
+
+// SMSBatchXSendWithPhonesChunked 批量模板分批群发，号码超过接口上限时自动拆分为多次请求
+func (c *Client) SMSBatchXSendWithPhonesChunked(project string, phones []string, vars map[string]string, signature, tag string, opts *ChunkOptions) (*SMSBatchSendResponse, error) {
+	return c.SMSBatchXSendWithPhonesChunkedCtx(context.Background(), project, phones, vars, signature, tag, opts)
+}
+
+// SMSBatchXSendWithPhonesChunkedCtx 批量模板分批群发（支持 context 取消与超时控制）
+// 合并规则同 SMSBatchSendWithPhonesChunkedCtx
+func (c *Client) SMSBatchXSendWithPhonesChunkedCtx(ctx context.Context, project string, phones []string, vars map[string]string, signature, tag string, opts *ChunkOptions) (*SMSBatchSendResponse, error) {
+	return c.batchChunked(ctx, phones, opts, func(ctx context.Context, chunkPhones []string) (*SMSBatchSendResponse, error) {
+		return c.SMSBatchXSendWithPhonesCtx(ctx, project, chunkPhones, vars, signature, tag)
+	})
+}
+
+// batchChunked 分批执行批量群发并合并结果，所有批次成功时 Status 为 success
+func (c *Client) batchChunked(ctx context.Context, phones []string, opts *ChunkOptions, send func(ctx context.Context, phones []string) (*SMSBatchSendResponse, error)) (*SMSBatchSendResponse, error) {
+	chunks := planChunks(len(phones), MaxBatchRecipients, opts)
+	responses := make([]*SMSBatchSendResponse, len(chunks))
+	results := make([][]SMSSendResult, len(chunks))
+	chunkErr := c.runChunks(ctx, chunks, opts, func(ctx context.Context, ch chunk) error {
+		resp, err := send(ctx, phones[ch.start:ch.end])
+		if err != nil {
+			return err
+		}
+		responses[ch.index] = resp
+		results[ch.index] = resp.Responses
+		return nil
+	})
+
+	merged := &SMSBatchSendResponse{BaseResponse: BaseResponse{Status: "success"}}
+	var batchLists []string
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		if resp.BatchList != "" {
+			batchLists = append(batchLists, resp.BatchList)
+		}
+		merged.TotalFee += resp.TotalFee
+	}
+	merged.BatchList = strings.Join(batchLists, ",")
+	merged.Responses = mergeChunks(chunks, results, phones, chunkErr)
+
+	if chunkErr != nil {
+		merged.Status = "error"
+		return merged, chunkErr
+	}
+	return merged, nil
+}
@@ -0,0 +1,117 @@
+package submail_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+// phones 生成 n 个连续的测试号码
+func phones(n int) []string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("138%08d", i)
+	}
+	return list
+}
+
+func TestMultiSendChunkedPreservesOrder(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	var items []submail.SMSMultiItem
+	for _, p := range phones(5) {
+		items = append(items, submail.SMSMultiItem{To: p})
+	}
+	resp, err := client.SMSMultiSendChunked(&submail.SMSMultiSendRequest{Content: "【测试】您好", Multi: items},
+		&submail.ChunkOptions{ChunkSize: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("SMSMultiSendChunked: %v", err)
+	}
+	if n := countRequests(server, submail.EndpointSMSMultiSend); n != 3 {
+		t.Fatalf("sent %d requests, want 3", n)
+	}
+	if len(*resp) != 5 {
+		t.Fatalf("got %d results, want 5", len(*resp))
+	}
+	for i, r := range *resp {
+		if r.To != items[i].To || r.Status != "success" {
+			t.Fatalf("result %d = %+v, want %s", i, r, items[i].To)
+		}
+	}
+}
+
+func TestMultiSendChunkedPartialFailure(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	var items []submail.SMSMultiItem
+	for _, p := range phones(5) {
+		items = append(items, submail.SMSMultiItem{To: p})
+	}
+	server.FailNext(submail.EndpointSMSMultiSend, submail.ErrInvalidMultiParam)
+	resp, err := client.SMSMultiSendChunked(&submail.SMSMultiSendRequest{Content: "【测试】您好", Multi: items},
+		&submail.ChunkOptions{ChunkSize: 2})
+
+	var chunkedErr *submail.ChunkedError
+	if !errors.As(err, &chunkedErr) || chunkedErr.Total != 3 || len(chunkedErr.Chunks) != 1 {
+		t.Fatalf("err = %v, want one failed chunk of 3", err)
+	}
+	if c := chunkedErr.Chunks[0]; c.Index != 0 || c.Offset != 0 || c.Size != 2 {
+		t.Fatalf("failed chunk = %+v", c)
+	}
+	if !errors.Is(err, submail.ErrorCode(submail.ErrInvalidMultiParam)) {
+		t.Fatalf("err = %v, want to match the chunk's API error", err)
+	}
+
+	success, failed, _ := resp.GetStatistics()
+	if success != 3 || failed != 2 || (*resp)[0].Code != submail.ErrInvalidMultiParam || (*resp)[0].To != items[0].To {
+		t.Fatalf("results = %+v", *resp)
+	}
+}
+
+func TestBatchSendChunkedMergesResponses(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	list := phones(5)
+	resp, err := client.SMSBatchSendWithPhonesChunked("【测试】您好", list, "", &submail.ChunkOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("SMSBatchSendWithPhonesChunked: %v", err)
+	}
+	if len(strings.Split(resp.BatchList, ",")) != 3 || resp.TotalFee != 5 || len(resp.Responses) != 5 {
+		t.Fatalf("merged response = %+v", resp)
+	}
+	for i, r := range resp.Responses {
+		if r.To != list[i] {
+			t.Fatalf("result %d to %s, want %s", i, r.To, list[i])
+		}
+	}
+}
+
+func TestChunkedCanceled(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := client.SMSBatchSendWithPhonesChunkedCtx(ctx, "【测试】您好", phones(3), "", &submail.ChunkOptions{ChunkSize: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if resp.Status != "error" || len(resp.Responses) != 3 {
+		t.Fatalf("response = %+v", resp)
+	}
+	if n := len(server.Messages()); n != 0 {
+		t.Fatalf("server received %d messages, want 0", n)
+	}
+}
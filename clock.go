@@ -0,0 +1,151 @@
+package submail
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultTimestampSyncInterval 默认的服务器时间偏移刷新间隔
+const DefaultTimestampSyncInterval = 10 * time.Minute
+
+// serverClock 服务器时钟
+// 缓存本地时间与 /service/timestamp 之间的偏移量，数字签名时直接在本地计算时间戳，
+// 避免每次请求前都额外请求一次时间戳API
+type serverClock struct {
+	mu       sync.Mutex
+	interval time.Duration // 刷新间隔，小于0表示不缓存（每次都从服务器获取）
+	offset   time.Duration // 偏移量：服务器时间 - 本地时间
+	syncedAt time.Time     // 上次同步的本地时间，零值表示尚未同步
+	inflight *clockSync    // 进行中的同步请求，为nil时没有
+}
+
+// clockSync 进行中的同步请求，并发的调用方等待同一个请求的结果
+type clockSync struct {
+	done chan struct{} // 请求完成后关闭
+	err  error         // 请求结果，done 关闭后可读
+}
+
+// newServerClock 创建服务器时钟
+func newServerClock(interval time.Duration) *serverClock {
+	if interval == 0 {
+		interval = DefaultTimestampSyncInterval
+	}
+	return &serverClock{interval: interval}
+}
+
+// disabled 是否关闭了偏移缓存
+func (sc *serverClock) disabled() bool {
+	return sc.interval < 0
+}
+
+// getOffset 获取当前缓存的偏移量
+func (sc *serverClock) getOffset() (time.Duration, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.offset, !sc.syncedAt.IsZero()
+}
+
+// invalidate 使缓存的偏移量失效，下一次签名时重新同步
+func (sc *serverClock) invalidate() {
+	sc.mu.Lock()
+	sc.syncedAt = time.Time{}
+	sc.mu.Unlock()
+}
+
+// now 返回按缓存偏移量校正后的服务器时间戳，偏移量过期时通过 fetch 重新同步
+func (sc *serverClock) now(ctx context.Context, fetch func(context.Context) (int64, error)) (int64, error) {
+	if err := sc.refresh(ctx, fetch, false); err != nil {
+		return 0, err
+	}
+	offset, _ := sc.getOffset()
+	return time.Now().Add(offset).Unix(), nil
+}
+
+// sync 立即请求服务器时间戳并计算偏移量
+func (sc *serverClock) sync(ctx context.Context, fetch func(context.Context) (int64, error)) error {
+	return sc.refresh(ctx, fetch, true)
+}
+
+// refresh 偏移量过期（或 force 为 true）时同步
+// 同一时间只发出一个时间戳请求，其他调用方等待该请求的结果；
+// 请求期间不持有锁：时间戳接口返回 151/152 时错误处理会调用 invalidate
+func (sc *serverClock) refresh(ctx context.Context, fetch func(context.Context) (int64, error), force bool) error {
+	sc.mu.Lock()
+	if !force && !sc.syncedAt.IsZero() && time.Since(sc.syncedAt) < sc.interval {
+		sc.mu.Unlock()
+		return nil
+	}
+	if call := sc.inflight; call != nil {
+		sc.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if (errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded)) && ctx.Err() == nil {
+			// 发起请求的调用方已取消，由当前调用方重新发起
+			return sc.refresh(ctx, fetch, force)
+		}
+		return call.err
+	}
+	call := &clockSync{done: make(chan struct{})}
+	sc.inflight = call
+	sc.mu.Unlock()
+
+	call.err = sc.fetch(ctx, fetch)
+
+	sc.mu.Lock()
+	sc.inflight = nil
+	sc.mu.Unlock()
+	close(call.done)
+	return call.err
+}
+
+// fetch 请求服务器时间戳并保存偏移量
+func (sc *serverClock) fetch(ctx context.Context, fetch func(context.Context) (int64, error)) error {
+	before := time.Now()
+	timestamp, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+	after := time.Now()
+
+	// 以请求往返的中点作为服务器生成时间戳时的本地时间
+	local := before.Add(after.Sub(before) / 2)
+
+	sc.mu.Lock()
+	sc.offset = time.Unix(timestamp, 0).Sub(local)
+	sc.syncedAt = after
+	sc.mu.Unlock()
+	return nil
+}
+
+// signTimestamp 获取数字签名使用的时间戳
+func (c *Client) signTimestamp(ctx context.Context) (int64, error) {
+	if c.dryRun {
+		// 演练模式不访问网络，直接使用本地时间
+		return time.Now().Unix(), nil
+	}
+	if c.clock.disabled() {
+		// 关闭缓存时保持原有行为：每次请求前获取服务器时间戳
+		return c.getTimestampFromServer(ctx)
+	}
+	return c.clock.now(ctx, c.getTimestampFromServer)
+}
+
+// SyncServerTime 立即与服务器同步时间偏移
+func (c *Client) SyncServerTime() error {
+	return c.SyncServerTimeCtx(context.Background())
+}
+
+// SyncServerTimeCtx 立即与服务器同步时间偏移（支持 context 取消与超时控制）
+func (c *Client) SyncServerTimeCtx(ctx context.Context) error {
+	return c.clock.sync(ctx, c.getTimestampFromServer)
+}
+
+// ServerTimeOffset 获取缓存的服务器时间偏移（服务器时间 - 本地时间），尚未同步时 ok 为 false
+func (c *Client) ServerTimeOffset() (offset time.Duration, ok bool) {
+	return c.clock.getOffset()
+}
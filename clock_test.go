@@ -0,0 +1,127 @@
+package submail_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+// countRequests 统计模拟服务器收到的指定接口请求数
+func countRequests(server *submailtest.Server, endpoint string) int {
+	n := 0
+	for _, req := range server.Requests() {
+		if req.Endpoint == endpoint {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDigitalSignCachesServerOffset(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	server.Now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	config := server.Config()
+	config.UseDigitalSign = true
+	client := submail.NewClient(config)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.SMSBalance(); err != nil {
+			t.Fatalf("SMSBalance #%d: %v", i, err)
+		}
+	}
+	if n := countRequests(server, submail.EndpointServiceTimestamp); n != 1 {
+		t.Fatalf("timestamp requested %d times, want 1", n)
+	}
+	offset, ok := client.ServerTimeOffset()
+	if !ok || offset < 59*time.Minute || offset > 61*time.Minute {
+		t.Fatalf("offset = %v (ok=%v), want about 1h", offset, ok)
+	}
+}
+
+func TestDigitalSignWithoutCache(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.UseDigitalSign = true
+	config.TimestampSyncInterval = -1
+	client := submail.NewClient(config)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.SMSBalance(); err != nil {
+			t.Fatalf("SMSBalance #%d: %v", i, err)
+		}
+	}
+	if n := countRequests(server, submail.EndpointServiceTimestamp); n != 2 {
+		t.Fatalf("timestamp requested %d times, want 2", n)
+	}
+}
+
+func TestTimestampErrorResyncsWithoutDeadlock(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.UseDigitalSign = true
+	client := submail.NewClient(config)
+
+	// 时间戳接口本身返回 151 时，错误处理会在同步过程中使缓存失效
+	server.FailNext(submail.EndpointServiceTimestamp, submail.ErrTimestampError)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.SMSBalance()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !submail.IsAPIErrorCode(err, submail.ErrTimestampError) {
+			t.Fatalf("err = %v, want API error 151", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SMSBalance deadlocked while invalidating the clock")
+	}
+
+	// 之后的请求重新同步并成功
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance after resync: %v", err)
+	}
+	if _, ok := client.ServerTimeOffset(); !ok {
+		t.Fatal("offset not synced after recovery")
+	}
+}
+
+func TestConcurrentSignersShareOneSync(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.UseDigitalSign = true
+	client := submail.NewClient(config)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.SMSBalance()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("SMSBalance: %v", err)
+		}
+	}
+	if n := countRequests(server, submail.EndpointServiceTimestamp); n != 1 {
+		t.Fatalf("timestamp requested %d times by concurrent signers, want 1", n)
+	}
+}
@@ -0,0 +1,311 @@
+package submail
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ResponseDecoder 响应解码器，根据 Config.Format 选择 JSON 或 XML 实现
+type ResponseDecoder interface {
+	// Decode 将响应内容解码到 v（v 必须为指针）
+	Decode(data []byte, v interface{}) error
+	// DecodeError 从响应中解析API错误，非错误响应返回 nil
+	DecodeError(data []byte) error
+}
+
+// NewResponseDecoder 根据响应格式创建解码器
+func NewResponseDecoder(format string) ResponseDecoder {
+	return newResponseDecoder(format, "")
+}
+
+// newResponseDecoder 创建使用指定语言的解码器
+func newResponseDecoder(format, locale string) ResponseDecoder {
+	if format == FormatXML {
+		return xmlDecoder{locale: locale}
+	}
+	return jsonDecoder{}
+}
+
+// ===== JSON 解码器 =====
+
+// jsonDecoder JSON响应解码器
+type jsonDecoder struct{}
+
+// Decode 解码JSON响应
+func (jsonDecoder) Decode(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// DecodeError 解析JSON格式的错误响应
+func (jsonDecoder) DecodeError(data []byte) error {
+	return ParseAPIError(data)
+}
+
+// ===== XML 解码器 =====
+
+// xmlDecoder XML响应解码器
+// 先将XML解析为通用节点树，再按照目标类型的 xml 标签转换为与JSON等价的结构后解码，
+// 这样可以复用 dto.go 中的同一套结构体（包括数组、map 字段）
+type xmlDecoder struct {
+	locale string // 错误信息语言，为空时使用默认语言
+}
+
+// xmlNode 通用XML节点
+type xmlNode struct {
+	XMLName xml.Name
+	Content string    `xml:",chardata"`
+	Nodes   []xmlNode `xml:",any"`
+}
+
+// Decode 解码XML响应
+func (d xmlDecoder) Decode(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return localizedError(d.locale, msgXMLDecodeTarget)
+	}
+
+	var root xmlNode
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	t := rv.Type().Elem()
+	var value interface{}
+	if isListType(t) {
+		// 顶层为数组（如 SMSMultiSendResponse），根节点的子节点即为各个元素
+		value = xmlListValue([]xmlNode{root}, t)
+	} else {
+		value = xmlNodeValue(&root, t)
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// DecodeError 解析XML格式的错误响应
+func (xmlDecoder) DecodeError(data []byte) error {
+	return ParseXMLAPIError(data)
+}
+
+// ParseXMLAPIError 从XML响应中解析API错误
+func ParseXMLAPIError(data []byte) error {
+	var errorResp struct {
+		Status string `xml:"status"`
+		Code   string `xml:"code"`
+		Msg    string `xml:"msg"`
+	}
+
+	if err := xml.Unmarshal(data, &errorResp); err != nil {
+		// 如果无法解析为错误格式，说明可能是正常响应
+		return nil
+	}
+
+	// 只有明确标记为error状态的响应才认为是错误
+	if strings.TrimSpace(errorResp.Status) != "error" {
+		return nil
+	}
+
+	code, _ := strconv.Atoi(strings.TrimSpace(errorResp.Code))
+	return NewAPIError(code, strings.TrimSpace(errorResp.Msg))
+}
+
+// isListType 判断是否为数组类型（[]byte 除外）
+func isListType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+// xmlNodeValue 按目标类型将XML节点转换为可JSON序列化的值
+func xmlNodeValue(n *xmlNode, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	text := strings.TrimSpace(n.Content)
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m := make(map[string]interface{})
+		fillXMLStruct(m, n, t)
+		return m
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return text
+		}
+		return xmlListValue([]xmlNode{*n}, t)
+
+	case reflect.Map:
+		m := make(map[string]interface{})
+		for i := range n.Nodes {
+			child := &n.Nodes[i]
+			m[child.XMLName.Local] = xmlNodeValue(child, t.Elem())
+		}
+		return m
+
+	case reflect.String:
+		return n.Content
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil
+		}
+		return json.Number(text)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil
+		}
+		return b
+
+	default:
+		// interface{} 等类型：叶子节点返回文本，否则按子节点名称转换为 map
+		if len(n.Nodes) == 0 {
+			return n.Content
+		}
+		m := make(map[string]interface{})
+		for i := range n.Nodes {
+			child := &n.Nodes[i]
+			m[child.XMLName.Local] = xmlNodeValue(child, t)
+		}
+		return m
+	}
+}
+
+// xmlListValue 将同名节点转换为数组
+// 支持两种写法：重复出现的同名元素，以及包含若干子元素（如 <item>）的容器元素
+func xmlListValue(nodes []xmlNode, t reflect.Type) []interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	elem := t.Elem()
+
+	items := nodes
+	if len(nodes) == 1 && isXMLContainer(&nodes[0], elem) {
+		items = nodes[0].Nodes
+	}
+
+	list := make([]interface{}, 0, len(items))
+	for i := range items {
+		list = append(list, xmlNodeValue(&items[i], elem))
+	}
+	return list
+}
+
+// isXMLContainer 判断节点是数组的容器元素，还是数组中的单个元素
+func isXMLContainer(n *xmlNode, elem reflect.Type) bool {
+	if len(n.Nodes) == 0 {
+		return false
+	}
+
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return true
+	}
+
+	// 子节点名称与元素结构体的字段相匹配，说明该节点本身就是一个元素
+	names := xmlFieldNames(elem)
+	for _, child := range n.Nodes {
+		if names[child.XMLName.Local] {
+			return false
+		}
+	}
+	return true
+}
+
+// xmlFieldNames 获取结构体（含嵌入结构体）的所有 xml 字段名
+func xmlFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("xml") == "" {
+			for name := range xmlFieldNames(f.Type) {
+				names[name] = true
+			}
+			continue
+		}
+		if name := xmlFieldName(f); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// fillXMLStruct 按结构体字段的 xml 标签从子节点中取值，并以 json 标签名写入 m
+func fillXMLStruct(m map[string]interface{}, n *xmlNode, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		// 嵌入结构体（如 BaseResponse）的字段展开到同一层级
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("xml") == "" {
+			fillXMLStruct(m, n, f.Type)
+			continue
+		}
+
+		xmlName := xmlFieldName(f)
+		jsonName := jsonFieldName(f)
+		if xmlName == "" || jsonName == "" {
+			continue
+		}
+
+		var children []xmlNode
+		for _, child := range n.Nodes {
+			if child.XMLName.Local == xmlName {
+				children = append(children, child)
+			}
+		}
+		if len(children) == 0 {
+			continue
+		}
+
+		var value interface{}
+		if isListType(f.Type) {
+			value = xmlListValue(children, f.Type)
+		} else {
+			value = xmlNodeValue(&children[0], f.Type)
+		}
+		if value != nil {
+			m[jsonName] = value
+		}
+	}
+}
+
+// xmlFieldName 获取字段的 xml 元素名
+func xmlFieldName(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		return ""
+	}
+	name := strings.Split(f.Tag.Get("xml"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// jsonFieldName 获取字段的 json 键名
+func jsonFieldName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
@@ -0,0 +1,82 @@
+package submail_test
+
+import (
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestXMLDecoderDecodesStruct(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<root><status>success</status><send_id>093c0a7df143c087d6cba9cdf0cf3738</send_id><fee>1</fee><sms>1</sms></root>`)
+
+	var resp submail.SMSSendResponse
+	if err := submail.NewResponseDecoder(submail.FormatXML).Decode(data, &resp); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if resp.Status != "success" || resp.SendID != "093c0a7df143c087d6cba9cdf0cf3738" || resp.Fee != 1 || resp.Sms != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestXMLDecoderDecodesList(t *testing.T) {
+	data := []byte(`<root>
+<item><status>success</status><to>13800138000</to><send_id>a1</send_id><fee>1</fee></item>
+<item><status>error</status><to>13800138001</to><code>114</code><msg>blacklisted</msg></item>
+</root>`)
+
+	var resp submail.SMSMultiSendResponse
+	if err := submail.NewResponseDecoder(submail.FormatXML).Decode(data, &resp); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("decoded %d results, want 2", len(resp))
+	}
+	if resp[0].To != "13800138000" || resp[0].Fee != 1 || resp[1].Code != 114 {
+		t.Fatalf("unexpected results: %+v", resp)
+	}
+}
+
+func TestParseXMLAPIError(t *testing.T) {
+	err := submail.ParseXMLAPIError([]byte(`<root><status>error</status><code>101</code><msg>Incorrect app id</msg></root>`))
+	if !submail.IsAPIErrorCode(err, submail.ErrIncorrectAppID) {
+		t.Fatalf("err = %v, want API error 101", err)
+	}
+	if err := submail.ParseXMLAPIError([]byte(`<root><status>success</status></root>`)); err != nil {
+		t.Fatalf("success response parsed as error: %v", err)
+	}
+}
+
+func TestClientXMLFormat(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.Format = submail.FormatXML
+	client := submail.NewClient(config)
+
+	resp, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】您好@var(name)",
+		Multi: []submail.SMSMultiItem{
+			{To: "13800138000", Vars: map[string]string{"name": "张三"}},
+			{To: "13800138001", Vars: map[string]string{"name": "李四"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SMSMultiSend: %v", err)
+	}
+	if len(*resp) != 2 || (*resp)[1].To != "13800138001" || (*resp)[1].Status != "success" {
+		t.Fatalf("unexpected response: %+v", *resp)
+	}
+	for _, req := range server.Requests() {
+		if req.Format != submail.FormatXML {
+			t.Fatalf("request %s used format %q, want xml", req.Endpoint, req.Format)
+		}
+	}
+
+	server.FailNext(submail.EndpointSMSBalance, submail.ErrIncorrectAppID)
+	if _, err := client.SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrIncorrectAppID) {
+		t.Fatalf("err = %v, want API error 101", err)
+	}
+}
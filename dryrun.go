@@ -0,0 +1,244 @@
+package submail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+// DryRunMessage 演练模式下本应发送的一条短信
+type DryRunMessage struct {
+	Endpoint  string            `json:"endpoint"`            // 发送接口，如 /sms/send
+	To        string            `json:"to"`                  // 收件人手机号码
+	Content   string            `json:"content,omitempty"`   // 短信正文（已处理变量；模板发送时为空）
+	Project   string            `json:"project,omitempty"`   // 模板ID（模板发送时）
+	Vars      map[string]string `json:"vars,omitempty"`      // 模板变量
+	Signature string            `json:"signature,omitempty"` // 自定义短信签名
+	Tag       string            `json:"tag,omitempty"`       // 自定义标签
+	SendID    string            `json:"send_id"`             // 合成的发送ID
+	Fee       int               `json:"fee"`                 // 预估计费条数
+	Params    map[string]string `json:"params"`              // 本应发送的请求参数（已包含认证参数，signature 已脱敏）
+	Time      time.Time         `json:"time"`                // 演练时间
+}
+
+// DryRunSink 演练消息输出
+type DryRunSink interface {
+	Write(ctx context.Context, msg *DryRunMessage) error
+}
+
+// DryRunSinkFunc 函数形式的演练消息输出
+type DryRunSinkFunc func(ctx context.Context, msg *DryRunMessage) error
+
+// Write 实现 DryRunSink 接口
+func (f DryRunSinkFunc) Write(ctx context.Context, msg *DryRunMessage) error {
+	return f(ctx, msg)
+}
+
+// NewLogDryRunSink 创建输出到日志的演练消息输出，logger 为nil时使用标准库默认logger
+func NewLogDryRunSink(logger *log.Logger) DryRunSink {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return DryRunSinkFunc(func(ctx context.Context, msg *DryRunMessage) error {
+		logger.Printf("[submail] dry-run %s to=%s project=%s send_id=%s fee=%d content=%q",
+			msg.Endpoint, msg.To, msg.Project, msg.SendID, msg.Fee, msg.Content)
+		return nil
+	})
+}
+
+// NewChannelDryRunSink 创建输出到 channel 的演练消息输出（channel 已满时阻塞，直到 ctx 取消）
+func NewChannelDryRunSink(ch chan<- DryRunMessage) DryRunSink {
+	return DryRunSinkFunc(func(ctx context.Context, msg *DryRunMessage) error {
+		select {
+		case ch <- *msg:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// FileDryRunSink 以 JSON Lines 格式追加写入文件的演练消息输出（并发安全）
+type FileDryRunSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileDryRunSink 创建写入文件的演练消息输出，文件不存在时自动创建
+func NewFileDryRunSink(path string) (*FileDryRunSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileDryRunSink{file: file}, nil
+}
+
+// Write 实现 DryRunSink 接口
+func (s *FileDryRunSink) Write(ctx context.Context, msg *DryRunMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close 关闭文件
+func (s *FileDryRunSink) Close() error {
+	return s.file.Close()
+}
+
+// ===== 演练模式 =====
+
+// dryRunEndpoints 演练模式下拦截的发送接口
+var dryRunEndpoints = map[string]bool{
+	EndpointSMSSend:       true,
+	EndpointSMSXSend:      true,
+	EndpointSMSMultiSend:  true,
+	EndpointSMSMultiXSend: true,
+	EndpointSMSBatchSend:  true,
+	EndpointSMSBatchXSend: true,
+	EndpointSMSUnionSend:  true,
+}
+
+// DryRun 是否处于演练模式
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
+
+// dryRunResponse 根据本应发送的请求参数合成发送响应，并将每条短信写入演练消息输出
+func (c *Client) dryRunResponse(ctx context.Context, info *RequestInfo) (*ResponseInfo, error) {
+	params := info.Params
+	base := DryRunMessage{
+		Endpoint:  info.Endpoint,
+		Project:   params["project"],
+		Signature: params["sms_signature"],
+		Tag:       params["tag"],
+		Params:    params,
+		Time:      time.Now(),
+	}
+	if params["vars"] != "" {
+		json.Unmarshal([]byte(params["vars"]), &base.Vars)
+	}
+
+	var messages []DryRunMessage
+	switch info.Endpoint {
+	case EndpointSMSSend, EndpointSMSXSend:
+		msg := base
+		msg.To = params["to"]
+		msg.Content = params["content"]
+		messages = append(messages, msg)
+
+	case EndpointSMSUnionSend:
+		msg := base
+		msg.To = params["to"]
+		msg.Content = params["content"]
+		if phone.IsInternational(msg.To) && params["inter_content"] != "" {
+			msg.Content = params["inter_content"]
+		}
+		messages = append(messages, msg)
+
+	case EndpointSMSMultiSend, EndpointSMSMultiXSend:
+		var items []SMSMultiXItem
+		json.Unmarshal([]byte(params["multi"]), &items)
+		for _, item := range items {
+			msg := base
+			msg.To = item.To
+			msg.Vars = item.Vars
+			if item.SMSSignature != "" {
+				msg.Signature = item.SMSSignature
+			}
+			if info.Endpoint == EndpointSMSMultiSend {
+				msg.Content = c.ProcessVariables(params["content"], item.Vars)
+			}
+			messages = append(messages, msg)
+		}
+
+	case EndpointSMSBatchSend, EndpointSMSBatchXSend:
+		for _, phone := range splitRecipients(params["to"]) {
+			msg := base
+			msg.To = phone
+			msg.Content = params["content"]
+			messages = append(messages, msg)
+		}
+	}
+
+	results := make([]SMSSendResult, 0, len(messages))
+	totalFee := 0
+	for i := range messages {
+		msg := &messages[i]
+		msg.SendID = newDryRunID()
+		// 模板发送时正文未知，计1条
+		if msg.Fee = SegmentCount(msg.Content, msg.Signature); msg.Fee == 0 {
+			msg.Fee = 1
+		}
+		if err := c.dryRunSink.Write(ctx, msg); err != nil {
+			return nil, c.errorf(msgDryRunSink, err)
+		}
+		results = append(results, SMSSendResult{Status: "success", To: msg.To, SendID: msg.SendID, Fee: msg.Fee})
+		totalFee += msg.Fee
+	}
+
+	var resp interface{}
+	switch info.Endpoint {
+	case EndpointSMSMultiSend, EndpointSMSMultiXSend:
+		resp = SMSMultiSendResponse(results)
+	case EndpointSMSBatchSend, EndpointSMSBatchXSend:
+		resp = &SMSBatchSendResponse{
+			BaseResponse: BaseResponse{Status: "success"},
+			BatchList:    newDryRunID(),
+			TotalFee:     totalFee,
+			Responses:    results,
+		}
+	default:
+		sendResp := &SMSSendResponse{BaseResponse: BaseResponse{Status: "success"}}
+		if len(results) > 0 {
+			sendResp.SendID = results[0].SendID
+			sendResp.Fee = results[0].Fee
+			sendResp.Sms = results[0].Fee
+		}
+		resp = sendResp
+	}
+
+	body, err := c.encodeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseInfo{StatusCode: http.StatusOK, Header: make(http.Header), Body: body}, nil
+}
+
+// encodeResponse 按客户端响应格式序列化合成的响应
+func (c *Client) encodeResponse(v interface{}) ([]byte, error) {
+	if c.format != FormatXML {
+		return json.Marshal(v)
+	}
+
+	// 顶层为数组时使用 <root><item>...</item></root> 结构，与解码器的约定一致
+	if results, ok := v.(SMSMultiSendResponse); ok {
+		return xml.Marshal(struct {
+			XMLName xml.Name        `xml:"root"`
+			Items   []SMSSendResult `xml:"item"`
+		}{Items: results})
+	}
+	return xml.Marshal(v)
+}
+
+// newDryRunID 生成演练模式的发送ID
+func newDryRunID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "dryrun-" + hex.EncodeToString(b)
+}
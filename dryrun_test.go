@@ -0,0 +1,94 @@
+package submail_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestDryRunDoesNotSend(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	ch := make(chan submail.DryRunMessage, 10)
+	config := server.Config()
+	config.DryRun = true
+	config.DryRunSink = submail.NewChannelDryRunSink(ch)
+	client := submail.NewClient(config)
+
+	resp, err := client.SMSSendWithVariables("13800138000", "【测试】您好@var(name)", map[string]string{"name": "张三"}, "")
+	if err != nil {
+		t.Fatalf("SMSSend: %v", err)
+	}
+	if resp.Status != "success" || resp.SendID == "" || resp.Fee != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	multi, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】您好",
+		Multi:   []submail.SMSMultiItem{{To: "13800138001"}, {To: "13800138002"}},
+	})
+	if err != nil || len(*multi) != 2 {
+		t.Fatalf("SMSMultiSend = %+v, %v", multi, err)
+	}
+
+	if n := countRequests(server, submail.EndpointSMSSend) + countRequests(server, submail.EndpointSMSMultiSend); n != 0 {
+		t.Fatalf("dry run sent %d requests", n)
+	}
+	if len(ch) != 3 {
+		t.Fatalf("sink received %d messages, want 3", len(ch))
+	}
+	msg := <-ch
+	if msg.To != "13800138000" || msg.Content != "【测试】您好张三" || msg.SendID != resp.SendID {
+		t.Fatalf("unexpected dry-run message: %+v", msg)
+	}
+	if msg.Params["signature"] == "test-key" {
+		t.Fatal("dry-run message leaks the app key")
+	}
+
+	// 查询接口照常请求
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+	if n := countRequests(server, submail.EndpointSMSBalance); n != 1 {
+		t.Fatalf("balance requested %d times, want 1", n)
+	}
+}
+
+func TestFileDryRunSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dryrun.jsonl")
+	sink, err := submail.NewFileDryRunSink(path)
+	if err != nil {
+		t.Fatalf("NewFileDryRunSink: %v", err)
+	}
+
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", DryRun: true, DryRunSink: sink})
+	if _, err := client.SMSBatchSend(&submail.SMSBatchSendRequest{To: "13800138000,13800138001", Content: "【测试】您好"}); err != nil {
+		t.Fatalf("SMSBatchSend: %v", err)
+	}
+	sink.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var lines []submail.DryRunMessage
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var msg submail.DryRunMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, msg)
+	}
+	if len(lines) != 2 || lines[1].To != "13800138001" || lines[1].Endpoint != submail.EndpointSMSBatchSend {
+		t.Fatalf("unexpected lines: %+v", lines)
+	}
+}
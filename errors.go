@@ -1,8 +1,12 @@
 package submail
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strconv"
 )
 
@@ -164,15 +168,15 @@ var ErrorMessages = map[int]string{
 
 // NewAPIError 创建API错误
 func NewAPIError(code int, msg string) *APIError {
-	description := ErrorMessages[code]
-	if description == "" {
-		description = "未知错误"
-	}
+	return NewAPIErrorWithLocale(LocaleZhCN, code, msg)
+}
 
+// NewAPIErrorWithLocale 创建使用指定语言描述的API错误
+func NewAPIErrorWithLocale(locale string, code int, msg string) *APIError {
 	return &APIError{
 		Code:        code,
 		Msg:         msg,
-		Description: description,
+		Description: GetErrorDescription(locale, code),
 	}
 }
 
@@ -208,3 +212,232 @@ func ParseAPIError(data []byte) error {
 
 	return NewAPIError(code, errorResp.Msg)
 }
+
+// AsAPIError 从错误链中提取API错误
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// ===== SDK 错误类型 =====
+
+// ErrTransport 网络传输错误（连接失败、超时、读取响应失败等），可使用 errors.Is 判断
+var ErrTransport error = sentinelError(msgRequestFailed)
+
+// transportError 网络传输错误（errors.Is(err, ErrTransport) 为 true）
+type transportError struct {
+	locale  string
+	read    bool // 是否为读取响应阶段的错误
+	written bool // 请求是否可能已写出（DNS解析、建立连接等阶段失败时为 false）
+	err     error
+}
+
+func (e *transportError) Error() string {
+	msg := localize(e.locale, msgRequestFailed)
+	if e.read {
+		msg += ": " + localize(e.locale, msgReadResponse)
+	}
+	return msg + ": " + e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// Is 支持 errors.Is(err, ErrTransport)
+func (e *transportError) Is(target error) bool {
+	return target == ErrTransport
+}
+
+// HTTPStatusError HTTP状态码错误（非2xx响应）
+type HTTPStatusError struct {
+	StatusCode int    // HTTP状态码
+	Body       []byte // 响应内容
+
+	locale string // 错误信息语言
+}
+
+func (e *HTTPStatusError) Error() string {
+	return localizef(e.locale, msgHTTPError, e.StatusCode, string(e.Body))
+}
+
+// ErrorCode API错误码，用于配合 errors.Is 判断API错误
+// 例如：errors.Is(err, submail.ErrorCode(submail.ErrPhoneInBlacklist))
+type ErrorCode int
+
+func (c ErrorCode) Error() string {
+	return fmt.Sprintf("SubMail API Error %d", int(c))
+}
+
+// Is 支持 errors.Is 按错误码匹配 ErrorCode 或 *APIError
+func (e *APIError) Is(target error) bool {
+	switch t := target.(type) {
+	case ErrorCode:
+		return e.Code == int(t)
+	case *APIError:
+		return t != nil && e.Code == t.Code
+	}
+	return false
+}
+
+// DecodeError 响应解析错误
+type DecodeError struct {
+	Target string // 解析目标，如 "短信发送响应"
+	Body   []byte // 原始响应内容
+	Err    error  // 底层错误
+
+	locale string // 错误信息语言
+}
+
+func (e *DecodeError) Error() string {
+	msg := localizef(e.locale, msgDecodeFailed, e.Target)
+	if e.Err != nil {
+		msg += fmt.Sprintf(": %v", e.Err)
+	}
+	if len(e.Body) > 0 {
+		body := string(e.Body)
+		if len(body) > 256 {
+			body = body[:256] + "..."
+		}
+		msg += ", " + localizef(e.locale, msgResponseBody, body)
+	}
+	return msg
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError 创建使用客户端语言的响应解析错误，target 为解析目标的消息键
+func (c *Client) decodeError(target string, body []byte, err error) *DecodeError {
+	return &DecodeError{Target: localize(c.locale, target), Body: body, Err: err, locale: c.locale}
+}
+
+// ValidationError 请求参数校验错误（请求未发出）
+type ValidationError struct {
+	Field   string // 参数名
+	Message string // 错误信息
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// validationError 创建使用客户端语言的参数校验错误
+func (c *Client) validationError(field, key string, args ...interface{}) *ValidationError {
+	return &ValidationError{Field: field, Message: localizef(c.locale, key, args...)}
+}
+
+// ===== 错误判断辅助函数 =====
+
+// IsAPIErrorCode 判断错误是否为指定错误码之一的API错误
+func IsAPIErrorCode(err error, codes ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable 判断错误是否为可重试的临时错误
+// 包括网络错误、HTTP 5xx/429 以及时间戳错误（151、152）
+func IsRetryable(err error) bool {
+	retryable, _ := classifyError(err, nil)
+	return retryable
+}
+
+// IsQuotaError 判断是否为配额或余额不足错误（901-905）
+func IsQuotaError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.Code >= ErrQuotaExhausted && apiErr.Code <= ErrInsufficientTransactionalSMS
+}
+
+// IsAuthError 判断是否为认证相关错误（应用、账户、签名参数错误 101-111，以及 IP 白名单错误 113）
+func IsAuthError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	return (apiErr.Code >= ErrIncorrectAppID && apiErr.Code <= ErrEmptySignatureParam) ||
+		apiErr.Code == ErrIPNotInWhitelist
+}
+
+// IsValidationError 判断是否为请求参数校验错误
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// classifyError 判断错误是否可重试，以及请求是否可能已被服务器受理
+// retryableCodes 为nil时使用默认的可重试错误码（151、152）
+func classifyError(err error, retryableCodes []int) (retryable, mayBeAccepted bool) {
+	if apiErr, ok := AsAPIError(err); ok {
+		if retryableCodes == nil {
+			retryableCodes = []int{ErrTimestampError, ErrInvalidTimestamp}
+		}
+		for _, code := range retryableCodes {
+			if apiErr.Code == code {
+				// API明确返回错误，请求未被受理
+				return true, false
+			}
+		}
+		return false, false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.StatusCode == http.StatusTooManyRequests {
+			return true, false
+		}
+		return statusErr.StatusCode >= 500, true
+	}
+
+	// 网络错误，请求未写出时一定未被受理
+	var netErr net.Error
+	if errors.Is(err, ErrTransport) || errors.As(err, &netErr) {
+		return true, requestMayBeSent(err)
+	}
+
+	return false, true
+}
+
+// isContextError 判断是否为 context 取消或超时导致的错误
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// requestMayBeSent 判断失败的请求是否可能已到达服务器，无法确定时视为可能已到达
+func requestMayBeSent(err error) bool {
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return transportErr.written
+	}
+	if _, ok := AsAPIError(err); ok {
+		return true
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return true
+	}
+
+	// 请求发出前的检查失败（参数校验、限流、熔断、屏蔽名单，以及等待期间 context 取消）
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrCircuitOpen) ||
+		errors.Is(err, ErrSuppressed) || isContextError(err) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return !isDialError(err)
+	}
+	return true
+}
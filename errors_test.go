@@ -0,0 +1,108 @@
+package submail_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestAPIErrorMatchesErrorCode(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	server.FailPhone("13800138000", submail.ErrPhoneInBlacklist)
+	_, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"})
+	if !errors.Is(err, submail.ErrorCode(submail.ErrPhoneInBlacklist)) {
+		t.Fatalf("err = %v, want ErrorCode(114)", err)
+	}
+	if errors.Is(err, submail.ErrorCode(submail.ErrContactUnsubscribed)) {
+		t.Fatal("err matched an unrelated error code")
+	}
+	apiErr, ok := submail.AsAPIError(err)
+	if !ok || apiErr.Description == "" {
+		t.Fatalf("AsAPIError = %+v, %v", apiErr, ok)
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	tests := []struct {
+		name                   string
+		err                    error
+		retryable, quota, auth bool
+	}{
+		{"timestamp", submail.NewAPIError(submail.ErrInvalidTimestamp, ""), true, false, false},
+		{"quota", submail.NewAPIError(submail.ErrInsufficientBalance, ""), false, true, false},
+		{"auth", submail.NewAPIError(submail.ErrInvalidAppKey, ""), false, false, true},
+		{"ip whitelist", submail.NewAPIError(submail.ErrIPNotInWhitelist, ""), false, false, true},
+		{"5xx", &submail.HTTPStatusError{StatusCode: 502}, true, false, false},
+		{"validation", &submail.ValidationError{Field: "to"}, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := submail.IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable = %v, want %v", got, tt.retryable)
+			}
+			if got := submail.IsQuotaError(tt.err); got != tt.quota {
+				t.Errorf("IsQuotaError = %v, want %v", got, tt.quota)
+			}
+			if got := submail.IsAuthError(tt.err); got != tt.auth {
+				t.Errorf("IsAuthError = %v, want %v", got, tt.auth)
+			}
+		})
+	}
+}
+
+func TestTransportErrorIsErrTransport(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", BaseURL: "http://" + addr})
+	_, err = client.SMSBalance()
+	if !errors.Is(err, submail.ErrTransport) {
+		t.Fatalf("err = %v, want ErrTransport", err)
+	}
+	if submail.IsValidationError(err) {
+		t.Fatal("transport error reported as validation error")
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>gateway</html>"))
+	}))
+	defer bad.Close()
+
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", BaseURL: bad.URL})
+	_, err := client.SMSBalance()
+	var decodeErr *submail.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("err = %v, want *DecodeError", err)
+	}
+	if string(decodeErr.Body) != "<html>gateway</html>" {
+		t.Fatalf("Body = %q", decodeErr.Body)
+	}
+}
+
+func TestValidationErrorBeforeRequest(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	_, err := client.SMSSend(&submail.SMSSendRequest{To: "", Content: "【测试】验证码1234"})
+	if !submail.IsValidationError(err) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want 0", n)
+	}
+}
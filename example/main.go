@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/phone"
 )
 
 func main() {
@@ -480,7 +481,7 @@ func main() {
 	// 测试号码判断功能
 	testNumbers := []string{"13800138000", "+8613800138000", "+1234567890", "+852987654321"}
 	for _, number := range testNumbers {
-		isInternational := submail.IsInternationalNumber(number)
+		isInternational := phone.IsInternational(number)
 		fmt.Printf("号码 %s: %s\n", number, map[bool]string{true: "国际号码", false: "国内号码"}[isInternational])
 	}
 
@@ -0,0 +1,298 @@
+package submail
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// API分组
+const (
+	EndpointFamilyAPI = "api" // 主API（api-v4.mysubmail.com）
+	EndpointFamilyLog = "log" // 日志API（log.mysubmail.com）
+)
+
+// 熔断器状态
+const (
+	CircuitClosed   = "closed"    // 正常
+	CircuitOpen     = "open"      // 已熔断，请求直接跳过该地址
+	CircuitHalfOpen = "half-open" // 半开，允许一个探测请求以判断是否恢复
+)
+
+// DefaultHealthCheckInterval StartHealthCheck 的默认探测间隔
+const DefaultHealthCheckInterval = 30 * time.Second
+
+// ErrCircuitOpen 所有地址均已熔断，可使用 errors.Is 判断
+var ErrCircuitOpen error = sentinelError(msgErrCircuitOpen)
+
+// circuitOpenError 熔断错误（errors.Is(err, ErrCircuitOpen) 为 true）
+type circuitOpenError struct {
+	locale string
+	family string
+}
+
+func (e *circuitOpenError) Error() string {
+	return localizef(e.locale, msgCircuitOpen, e.family)
+}
+
+// Is 支持 errors.Is(err, ErrCircuitOpen)
+func (e *circuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerConfig 熔断配置
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // 连续失败多少次后熔断（默认5）
+	OpenTimeout      time.Duration // 熔断持续时间，到期后进入半开状态尝试恢复（默认30秒）
+}
+
+// EndpointStatus 地址健康状态
+type EndpointStatus struct {
+	Family              string    // API分组：api 或 log
+	URL                 string    // 基础URL
+	State               string    // 熔断器状态：closed、open、half-open
+	ConsecutiveFailures int       // 连续失败次数
+	LastError           error     // 最近一次失败的错误
+	LastCheck           time.Time // 最近一次请求或探测时间
+}
+
+// endpointPool 同一API分组的多个基础URL
+type endpointPool struct {
+	family    string
+	endpoints []*endpoint
+	breaker   bool          // 是否启用熔断
+	threshold int           // 连续失败阈值
+	timeout   time.Duration // 熔断持续时间
+}
+
+// endpoint 单个基础URL及其熔断器
+type endpoint struct {
+	url string
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openedAt  time.Time
+	probing   bool // 半开状态下是否已有探测请求
+	lastErr   error
+	lastCheck time.Time
+}
+
+// newEndpointPool 创建地址池
+// 配置了多个地址或显式设置了熔断配置时启用熔断
+func newEndpointPool(family string, urls []string, config *CircuitBreakerConfig) *endpointPool {
+	pool := &endpointPool{
+		family:    family,
+		breaker:   config != nil || len(urls) > 1,
+		threshold: 5,
+		timeout:   30 * time.Second,
+	}
+	if config != nil {
+		if config.FailureThreshold > 0 {
+			pool.threshold = config.FailureThreshold
+		}
+		if config.OpenTimeout > 0 {
+			pool.timeout = config.OpenTimeout
+		}
+	}
+
+	for _, u := range urls {
+		pool.endpoints = append(pool.endpoints, &endpoint{url: u, state: CircuitClosed})
+	}
+	return pool
+}
+
+// acquire 按优先级选择可用地址，优先选择本次调用尚未尝试过的地址，probe 表示本次请求占用了半开状态的探测名额
+// 主地址恢复（半开探测成功）后会自动切回
+func (p *endpointPool) acquire(tried map[*endpoint]bool) (ep *endpoint, probe bool) {
+	for _, retry := range []bool{false, true} {
+		for _, ep := range p.endpoints {
+			if tried[ep] != retry {
+				continue
+			}
+			if ok, probe := ep.allow(p); ok {
+				return ep, probe
+			}
+		}
+	}
+	return nil, false
+}
+
+// hasAlternative 是否还有本次调用未尝试过且可用的地址
+func (p *endpointPool) hasAlternative(tried map[*endpoint]bool) bool {
+	for _, ep := range p.endpoints {
+		if !tried[ep] && ep.available(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// allow 判断地址是否可用，半开状态下只放行一个探测请求（probe 为 true）
+func (ep *endpoint) allow(p *endpointPool) (ok, probe bool) {
+	if !p.breaker {
+		return true, false
+	}
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	switch ep.state {
+	case CircuitOpen:
+		if time.Since(ep.openedAt) < p.timeout {
+			return false, false
+		}
+		ep.state = CircuitHalfOpen
+		ep.probing = true
+		return true, true
+	case CircuitHalfOpen:
+		if ep.probing {
+			return false, false
+		}
+		ep.probing = true
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// available 判断地址当前是否可用（不占用探测名额）
+func (ep *endpoint) available(p *endpointPool) bool {
+	if !p.breaker {
+		return true
+	}
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	switch ep.state {
+	case CircuitOpen:
+		return time.Since(ep.openedAt) >= p.timeout
+	case CircuitHalfOpen:
+		return !ep.probing
+	default:
+		return true
+	}
+}
+
+// record 记录请求结果，probe 为 true 时释放半开状态的探测名额（其他请求的结果不影响探测名额）
+// failed 为 true 表示地址故障（网络错误、HTTP 5xx）；neutral 为 true 表示结果无法说明地址健康状况（如调用方取消）
+func (ep *endpoint) record(p *endpointPool, probe, failed, neutral bool, err error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if probe {
+		ep.probing = false
+	}
+	if neutral {
+		return
+	}
+
+	ep.lastCheck = time.Now()
+	if !failed {
+		ep.failures = 0
+		ep.state = CircuitClosed
+		return
+	}
+
+	ep.failures++
+	ep.lastErr = err
+	if p.breaker && (ep.state == CircuitHalfOpen || ep.failures >= p.threshold) {
+		ep.state = CircuitOpen
+		ep.openedAt = time.Now()
+	}
+}
+
+// status 获取地址健康状态
+func (ep *endpoint) status(family string) EndpointStatus {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	return EndpointStatus{
+		Family:              family,
+		URL:                 ep.url,
+		State:               ep.state,
+		ConsecutiveFailures: ep.failures,
+		LastError:           ep.lastErr,
+		LastCheck:           ep.lastCheck,
+	}
+}
+
+// isEndpointFailure 判断错误是否说明地址故障（网络错误、HTTP 5xx）
+func isEndpointFailure(err error) bool {
+	if errors.Is(err, ErrTransport) {
+		return true
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// ===== 客户端方法 =====
+
+// EndpointStatuses 获取所有基础URL的健康状态
+func (c *Client) EndpointStatuses() []EndpointStatus {
+	var statuses []EndpointStatus
+	for _, pool := range []*endpointPool{c.apiPool, c.logPool} {
+		for _, ep := range pool.endpoints {
+			statuses = append(statuses, ep.status(pool.family))
+		}
+	}
+	return statuses
+}
+
+// CheckEndpoints 使用 ServiceStatus 接口探测所有主API地址，并更新熔断器状态
+func (c *Client) CheckEndpoints() []EndpointStatus {
+	return c.CheckEndpointsCtx(context.Background())
+}
+
+// CheckEndpointsCtx 使用 ServiceStatus 接口探测所有主API地址，并更新熔断器状态（支持 context 取消与超时控制）
+func (c *Client) CheckEndpointsCtx(ctx context.Context) []EndpointStatus {
+	statuses := make([]EndpointStatus, 0, len(c.apiPool.endpoints))
+	for _, ep := range c.apiPool.endpoints {
+		c.probeEndpoint(ctx, ep)
+		statuses = append(statuses, ep.status(c.apiPool.family))
+	}
+	return statuses
+}
+
+// StartHealthCheck 在后台定期探测主API地址，直到 ctx 取消，interval 小于等于0时使用 DefaultHealthCheckInterval
+func (c *Client) StartHealthCheck(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.CheckEndpointsCtx(ctx)
+			}
+		}
+	}()
+}
+
+// probeEndpoint 探测单个地址（忽略熔断状态，结果计入熔断器，服务状态异常视为地址故障）
+func (c *Client) probeEndpoint(ctx context.Context, ep *endpoint) {
+	spec := c.newFormRequestSpec("GET", EndpointServiceStatus, map[string]string{}, c.apiPool)
+	spec.target = ep
+	spec.healthCheck = func(body []byte) error {
+		var resp ServiceStatusResponse
+		if err := c.decoder.Decode(body, &resp); err != nil || resp.Status != "runing" {
+			return c.decodeError(targetServiceStatus, body, err)
+		}
+		return nil
+	}
+
+	c.execute(ctx, spec)
+}
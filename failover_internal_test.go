@@ -0,0 +1,36 @@
+package submail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHalfOpenAllowsSingleProbe(t *testing.T) {
+	pool := newEndpointPool(EndpointFamilyAPI, []string{"http://a"}, &CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+	ep := pool.endpoints[0]
+
+	ep.record(pool, false, true, false, nil)
+	time.Sleep(2 * time.Millisecond)
+
+	ok, probe := ep.allow(pool)
+	if !ok || !probe {
+		t.Fatalf("allow = %v, %v; want the probe slot", ok, probe)
+	}
+	if ok, _ := ep.allow(pool); ok {
+		t.Fatal("second request allowed while the probe is in flight")
+	}
+
+	// 非探测请求的结果不释放探测名额
+	ep.record(pool, false, false, true, nil)
+	if ok, _ := ep.allow(pool); ok {
+		t.Fatal("probe slot released by a non-probe result")
+	}
+
+	ep.record(pool, true, false, false, nil)
+	if ep.status(pool.family).State != CircuitClosed {
+		t.Fatal("successful probe did not close the circuit")
+	}
+	if ok, probe := ep.allow(pool); !ok || probe {
+		t.Fatalf("allow after recovery = %v, %v", ok, probe)
+	}
+}
@@ -0,0 +1,158 @@
+package submail_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+// deadURL 返回一个没有服务监听的地址
+func deadURL(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return "http://" + listener.Addr().String()
+}
+
+// endpointStatus 查找地址的健康状态
+func endpointStatus(client *submail.Client, url string) submail.EndpointStatus {
+	for _, status := range client.EndpointStatuses() {
+		if status.Family == submail.EndpointFamilyAPI && status.URL == url {
+			return status
+		}
+	}
+	return submail.EndpointStatus{}
+}
+
+func TestFailoverToSecondaryEndpoint(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	primary := deadURL(t)
+
+	config := server.Config()
+	config.BaseURLs = []string{primary, server.URL}
+	config.CircuitBreaker = &submail.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour}
+	client := submail.NewClient(config)
+
+	// 连接失败时请求一定未发出，发送接口同样可以切换地址
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}); err != nil {
+		t.Fatalf("SMSSend: %v", err)
+	}
+	if status := endpointStatus(client, primary); status.State != submail.CircuitOpen || status.ConsecutiveFailures != 1 {
+		t.Fatalf("primary status = %+v, want open after 1 failure", status)
+	}
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+	if n := len(server.Requests()); n != 2 {
+		t.Fatalf("secondary received %d requests, want 2", n)
+	}
+}
+
+func TestNoFailoverForPossiblyAcceptedSend(t *testing.T) {
+	primary := submailtest.NewServer("test-app", "test-key")
+	defer primary.Close()
+	secondary := submailtest.NewServer("test-app", "test-key")
+	defer secondary.Close()
+
+	config := primary.Config()
+	config.BaseURLs = []string{primary.URL, secondary.URL}
+	client := submail.NewClient(config)
+
+	primary.FailNextHTTP(submail.EndpointSMSSend, http.StatusBadGateway)
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}); err == nil {
+		t.Fatal("SMSSend succeeded, want HTTP error")
+	}
+	if n := len(secondary.Requests()); n != 0 {
+		t.Fatalf("send was repeated on the secondary endpoint (%d requests)", n)
+	}
+
+	// 幂等请求可以切换
+	primary.FailNextHTTP(submail.EndpointSMSBalance, http.StatusBadGateway)
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+	if n := len(secondary.Requests()); n != 1 {
+		t.Fatalf("secondary received %d requests, want 1", n)
+	}
+}
+
+func TestAllEndpointsOpen(t *testing.T) {
+	config := submail.Config{
+		AppID:          "test-app",
+		AppKey:         "test-key",
+		BaseURLs:       []string{deadURL(t), deadURL(t)},
+		CircuitBreaker: &submail.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour},
+	}
+	client := submail.NewClient(config)
+
+	if _, err := client.SMSBalance(); !errors.Is(err, submail.ErrTransport) {
+		t.Fatalf("first call err = %v, want ErrTransport", err)
+	}
+	if _, err := client.SMSBalance(); !errors.Is(err, submail.ErrCircuitOpen) {
+		t.Fatalf("second call err = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestHalfOpenRecovery(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.CircuitBreaker = &submail.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 50 * time.Millisecond}
+	client := submail.NewClient(config)
+
+	server.FailNextHTTP(submail.EndpointSMSBalance, http.StatusServiceUnavailable)
+	client.SMSBalance()
+	if _, err := client.SMSBalance(); !errors.Is(err, submail.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("probe request: %v", err)
+	}
+	if status := endpointStatus(client, server.URL); status.State != submail.CircuitClosed {
+		t.Fatalf("state = %s, want closed", status.State)
+	}
+}
+
+func TestCheckEndpointsRecordsOneProbe(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	primary := deadURL(t)
+
+	config := server.Config()
+	config.BaseURLs = []string{primary, server.URL}
+	client := submail.NewClient(config)
+
+	statuses := client.CheckEndpoints()
+	if len(statuses) != 2 {
+		t.Fatalf("got %d statuses, want 2", len(statuses))
+	}
+	if statuses[0].ConsecutiveFailures != 1 || statuses[0].LastError == nil {
+		t.Fatalf("primary = %+v, want exactly 1 failure", statuses[0])
+	}
+	if statuses[1].State != submail.CircuitClosed || statuses[1].ConsecutiveFailures != 0 {
+		t.Fatalf("secondary = %+v", statuses[1])
+	}
+}
+
+func TestStartHealthCheckDefaultInterval(t *testing.T) {
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// interval 小于等于0时使用默认间隔，不应 panic
+	client.StartHealthCheck(ctx, 0)
+	client.StartHealthCheck(ctx, -time.Second)
+}
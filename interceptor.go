@@ -0,0 +1,103 @@
+package submail
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// redactedValue 脱敏后的参数值
+const redactedValue = "******"
+
+// RequestInfo 拦截器可见的请求信息
+type RequestInfo struct {
+	Method    string            // HTTP方法
+	Endpoint  string            // API端点，如 /sms/send
+	URL       string            // 请求URL（不含查询参数）
+	Params    map[string]string // 最终请求参数（已包含认证参数，signature 已脱敏）
+	Header    http.Header       // 请求头，拦截器可在此添加自定义请求头
+	Multipart bool              // 是否为 multipart/form-data 请求
+	Attempt   int               // 第几次尝试（从1开始，重试时递增）
+}
+
+// ResponseInfo 拦截器可见的响应信息
+type ResponseInfo struct {
+	StatusCode int           // HTTP状态码
+	Header     http.Header   // 响应头
+	Body       []byte        // 原始响应内容
+	Duration   time.Duration // 请求耗时
+}
+
+// Invoker 执行请求（调用链中的下一个拦截器或最终的HTTP请求）
+// 返回的错误为解码后的错误：*APIError、*HTTPStatusError 或网络错误
+type Invoker func(ctx context.Context, req *RequestInfo) (*ResponseInfo, error)
+
+// Interceptor 请求拦截器
+// 可用于日志、监控指标、注入请求头、审计等，多个拦截器按添加顺序由外向内执行
+type Interceptor interface {
+	Intercept(ctx context.Context, req *RequestInfo, next Invoker) (*ResponseInfo, error)
+}
+
+// InterceptorFunc 函数形式的拦截器
+type InterceptorFunc func(ctx context.Context, req *RequestInfo, next Invoker) (*ResponseInfo, error)
+
+// Intercept 实现 Interceptor 接口
+func (f InterceptorFunc) Intercept(ctx context.Context, req *RequestInfo, next Invoker) (*ResponseInfo, error) {
+	return f(ctx, req, next)
+}
+
+// Use 追加请求拦截器（需在发起请求前调用，非并发安全）
+func (c *Client) Use(interceptors ...Interceptor) {
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+// intercept 依次通过拦截器链执行请求
+func (c *Client) intercept(ctx context.Context, req *RequestInfo, final Invoker) (*ResponseInfo, error) {
+	invoker := final
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], invoker
+		invoker = func(ctx context.Context, req *RequestInfo) (*ResponseInfo, error) {
+			return interceptor.Intercept(ctx, req, next)
+		}
+	}
+	return invoker(ctx, req)
+}
+
+// redactParams 复制请求参数并对签名脱敏
+func redactParams(params map[string]string) map[string]string {
+	redacted := make(map[string]string, len(params))
+	for k, v := range params {
+		if k == "signature" {
+			v = redactedValue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// NewLoggingInterceptor 创建记录请求日志的拦截器，logger 为nil时使用标准库默认logger
+func NewLoggingInterceptor(logger *log.Logger) Interceptor {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return InterceptorFunc(func(ctx context.Context, req *RequestInfo, next Invoker) (*ResponseInfo, error) {
+		start := time.Now()
+		resp, err := next(ctx, req)
+		elapsed := time.Since(start)
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		if err != nil {
+			logger.Printf("[submail] %s %s attempt=%d status=%d duration=%v error=%v",
+				req.Method, req.Endpoint, req.Attempt, status, elapsed, err)
+		} else {
+			logger.Printf("[submail] %s %s attempt=%d status=%d duration=%v",
+				req.Method, req.Endpoint, req.Attempt, status, elapsed)
+		}
+		return resp, err
+	})
+}
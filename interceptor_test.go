@@ -0,0 +1,117 @@
+package submail_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestInterceptorOrderAndRedaction(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	var mu sync.Mutex
+	var order []string
+	var seen *submail.RequestInfo
+	trace := func(name string) submail.Interceptor {
+		return submail.InterceptorFunc(func(ctx context.Context, req *submail.RequestInfo, next submail.Invoker) (*submail.ResponseInfo, error) {
+			mu.Lock()
+			order = append(order, name+">")
+			mu.Unlock()
+			resp, err := next(ctx, req)
+			mu.Lock()
+			order = append(order, "<"+name)
+			seen = req
+			mu.Unlock()
+			return resp, err
+		})
+	}
+
+	config := server.Config()
+	config.Interceptors = []submail.Interceptor{trace("a"), trace("b")}
+	client := submail.NewClient(config)
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+
+	if got := strings.Join(order, " "); got != "a> b> <b <a" {
+		t.Fatalf("order = %q", got)
+	}
+	if seen.Endpoint != submail.EndpointSMSBalance || seen.Attempt != 1 {
+		t.Fatalf("unexpected request info: %+v", seen)
+	}
+	if sig := seen.Params["signature"]; sig == "test-key" || sig == "" {
+		t.Fatalf("signature not redacted: %q", sig)
+	}
+}
+
+func TestInterceptorInjectsHeader(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	var got string
+	config := server.Config()
+	config.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get("X-Request-ID")
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := submail.NewClient(config)
+	client.Use(submail.InterceptorFunc(func(ctx context.Context, req *submail.RequestInfo, next submail.Invoker) (*submail.ResponseInfo, error) {
+		req.Header.Set("X-Request-ID", "req-1")
+		return next(ctx, req)
+	}))
+
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+	if got != "req-1" {
+		t.Fatalf("X-Request-ID = %q, want req-1", got)
+	}
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	blocked := errors.New("blocked")
+	client := submail.NewClient(server.Config())
+	client.Use(submail.InterceptorFunc(func(ctx context.Context, req *submail.RequestInfo, next submail.Invoker) (*submail.ResponseInfo, error) {
+		return nil, blocked
+	}))
+
+	if _, err := client.SMSBalance(); !errors.Is(err, blocked) {
+		t.Fatalf("err = %v, want blocked", err)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want 0", n)
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	var buf bytes.Buffer
+	config := server.Config()
+	config.Interceptors = []submail.Interceptor{submail.NewLoggingInterceptor(log.New(&buf, "", 0))}
+	client := submail.NewClient(config)
+
+	server.FailNext(submail.EndpointSMSBalance, submail.ErrIncorrectAppID)
+	client.SMSBalance()
+
+	out := buf.String()
+	if !strings.Contains(out, submail.EndpointSMSBalance) || !strings.Contains(out, "error=") {
+		t.Fatalf("unexpected log output: %q", out)
+	}
+	if strings.Contains(out, "test-key") {
+		t.Fatalf("log output leaks the app key: %q", out)
+	}
+}
@@ -0,0 +1,81 @@
+package submail
+
+import "context"
+
+// ===== 接口定义 =====
+// 业务代码可以依赖以下小接口而不是具体的 *Client，单元测试时使用 submailtest.Recorder 等实现替换
+
+// SMSSender 短信发送
+type SMSSender interface {
+	SMSSend(req *SMSSendRequest) (*SMSSendResponse, error)
+	SMSSendCtx(ctx context.Context, req *SMSSendRequest) (*SMSSendResponse, error)
+	SMSXSend(req *SMSXSendRequest) (*SMSSendResponse, error)
+	SMSXSendCtx(ctx context.Context, req *SMSXSendRequest) (*SMSSendResponse, error)
+	SMSMultiSend(req *SMSMultiSendRequest) (*SMSMultiSendResponse, error)
+	SMSMultiSendCtx(ctx context.Context, req *SMSMultiSendRequest) (*SMSMultiSendResponse, error)
+	SMSMultiXSend(req *SMSMultiXSendRequest) (*SMSMultiSendResponse, error)
+	SMSMultiXSendCtx(ctx context.Context, req *SMSMultiXSendRequest) (*SMSMultiSendResponse, error)
+	SMSBatchSend(req *SMSBatchSendRequest) (*SMSBatchSendResponse, error)
+	SMSBatchSendCtx(ctx context.Context, req *SMSBatchSendRequest) (*SMSBatchSendResponse, error)
+	SMSBatchXSend(req *SMSBatchXSendRequest) (*SMSBatchSendResponse, error)
+	SMSBatchXSendCtx(ctx context.Context, req *SMSBatchXSendRequest) (*SMSBatchSendResponse, error)
+	SMSUnionSend(req *SMSUnionSendRequest) (*SMSSendResponse, error)
+	SMSUnionSendCtx(ctx context.Context, req *SMSUnionSendRequest) (*SMSSendResponse, error)
+}
+
+// TemplateManager 短信模板管理
+type TemplateManager interface {
+	SMSTemplateGet(req *SMSTemplateGetRequest) (*SMSTemplateGetResponse, error)
+	SMSTemplateGetCtx(ctx context.Context, req *SMSTemplateGetRequest) (*SMSTemplateGetResponse, error)
+	SMSTemplateCreate(req *SMSTemplateCreateRequest) (*SMSTemplateCreateResponse, error)
+	SMSTemplateCreateCtx(ctx context.Context, req *SMSTemplateCreateRequest) (*SMSTemplateCreateResponse, error)
+	SMSTemplateUpdate(req *SMSTemplateUpdateRequest) (*SMSTemplateOperationResponse, error)
+	SMSTemplateUpdateCtx(ctx context.Context, req *SMSTemplateUpdateRequest) (*SMSTemplateOperationResponse, error)
+	SMSTemplateDelete(req *SMSTemplateDeleteRequest) (*SMSTemplateOperationResponse, error)
+	SMSTemplateDeleteCtx(ctx context.Context, req *SMSTemplateDeleteRequest) (*SMSTemplateOperationResponse, error)
+}
+
+// SignatureManager 短信签名管理
+type SignatureManager interface {
+	SMSSignatureQuery(req *SMSSignatureQueryRequest) (*SMSSignatureQueryResponse, error)
+	SMSSignatureQueryCtx(ctx context.Context, req *SMSSignatureQueryRequest) (*SMSSignatureQueryResponse, error)
+	SMSSignatureCreate(req *SMSSignatureCreateRequest) (*SMSSignatureOperationResponse, error)
+	SMSSignatureCreateCtx(ctx context.Context, req *SMSSignatureCreateRequest) (*SMSSignatureOperationResponse, error)
+	SMSSignatureUpdate(req *SMSSignatureUpdateRequest) (*SMSSignatureOperationResponse, error)
+	SMSSignatureUpdateCtx(ctx context.Context, req *SMSSignatureUpdateRequest) (*SMSSignatureOperationResponse, error)
+	SMSSignatureDelete(req *SMSSignatureDeleteRequest) (*SMSSignatureOperationResponse, error)
+	SMSSignatureDeleteCtx(ctx context.Context, req *SMSSignatureDeleteRequest) (*SMSSignatureOperationResponse, error)
+}
+
+// LogQuerier 发送记录、上行短信、统计报告及余额查询
+type LogQuerier interface {
+	SMSLog(req *SMSLogRequest) (*SMSLogResponse, error)
+	SMSLogCtx(ctx context.Context, req *SMSLogRequest) (*SMSLogResponse, error)
+	SMSMO(req *SMSMORequest) (*SMSMOResponse, error)
+	SMSMOCtx(ctx context.Context, req *SMSMORequest) (*SMSMOResponse, error)
+	SMSReports(req *SMSReportsRequest) (*SMSReportsResponse, error)
+	SMSReportsCtx(ctx context.Context, req *SMSReportsRequest) (*SMSReportsResponse, error)
+	SMSBalance() (*SMSBalanceResponse, error)
+	SMSBalanceCtx(ctx context.Context) (*SMSBalanceResponse, error)
+	SMSBalanceLog(req *SMSBalanceLogRequest) (*SMSBalanceLogResponse, error)
+	SMSBalanceLogCtx(ctx context.Context, req *SMSBalanceLogRequest) (*SMSBalanceLogResponse, error)
+}
+
+// SubhookManager SUBHOOK 管理
+type SubhookManager interface {
+	SubhookCreate(req *SubhookCreateRequest) (*SubhookCreateResponse, error)
+	SubhookCreateCtx(ctx context.Context, req *SubhookCreateRequest) (*SubhookCreateResponse, error)
+	SubhookQuery(req *SubhookQueryRequest) (*SubhookQueryResponse, error)
+	SubhookQueryCtx(ctx context.Context, req *SubhookQueryRequest) (*SubhookQueryResponse, error)
+	SubhookDelete(req *SubhookDeleteRequest) (*SubhookDeleteResponse, error)
+	SubhookDeleteCtx(ctx context.Context, req *SubhookDeleteRequest) (*SubhookDeleteResponse, error)
+}
+
+// 编译期检查 *Client 实现了所有接口
+var (
+	_ SMSSender        = (*Client)(nil)
+	_ TemplateManager  = (*Client)(nil)
+	_ SignatureManager = (*Client)(nil)
+	_ LogQuerier       = (*Client)(nil)
+	_ SubhookManager   = (*Client)(nil)
+)
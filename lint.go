@@ -0,0 +1,256 @@
+package submail
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 内容检查的长度限制（与API的校验规则一致）
+const (
+	maxSignatureLength = 10   // 短信签名最大字数（不含【】）
+	minSignatureLength = 2    // 短信签名最小字数（不含【】）
+	maxContentLength   = 1000 // 短信内容（含签名）最大字数
+	maxTitleLength     = 64   // 模板标题最大字数
+	maxTagLength       = 32   // 自定义标签最大长度
+)
+
+// 检查结果级别
+const (
+	LintError   = "error"   // 发送或提交时API会返回错误
+	LintWarning = "warning" // API不会拒绝，但内容可能不符合预期
+)
+
+// unresolvedVarPattern 未替换的 @var() 变量
+var unresolvedVarPattern = regexp.MustCompile(`@var\([^)]*\)`)
+
+// ForbiddenWords 禁用词词典
+type ForbiddenWords interface {
+	// Match 返回文本中出现的禁用词
+	Match(text string) []string
+}
+
+// ForbiddenWordsFunc 函数形式的禁用词词典
+type ForbiddenWordsFunc func(text string) []string
+
+// Match 实现 ForbiddenWords 接口
+func (f ForbiddenWordsFunc) Match(text string) []string {
+	return f(text)
+}
+
+// WordList 禁用词列表（不区分大小写的子串匹配）
+type WordList []string
+
+// Match 实现 ForbiddenWords 接口
+func (l WordList) Match(text string) []string {
+	lower := strings.ToLower(text)
+	var matched []string
+	for _, word := range l {
+		if word != "" && strings.Contains(lower, strings.ToLower(word)) {
+			matched = append(matched, word)
+		}
+	}
+	return matched
+}
+
+// LintFinding 一条内容检查结果
+type LintFinding struct {
+	Code     int    // 对应的API错误码（Err* 常量），无对应错误码时为0
+	Field    string // 参数名：content、sms_signature、sms_content、sms_title、tag
+	Severity string // 级别：LintError 或 LintWarning
+	Message  string // 描述（使用客户端语言）
+	Detail   string // 触发检查的内容，如禁用词、未替换的变量、实际字数
+}
+
+// LintFindings 内容检查结果列表
+type LintFindings []LintFinding
+
+// HasErrors 是否包含 LintError 级别的结果
+func (f LintFindings) HasErrors() bool {
+	for _, finding := range f {
+		if finding.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// Codes 获取所有结果对应的API错误码（不含0）
+func (f LintFindings) Codes() []int {
+	var codes []int
+	for _, finding := range f {
+		if finding.Code != 0 {
+			codes = append(codes, finding.Code)
+		}
+	}
+	return codes
+}
+
+// Err 将第一个 LintError 级别的结果转换为 *ValidationError，没有错误时返回nil
+func (f LintFindings) Err() error {
+	for _, finding := range f {
+		if finding.Severity != LintError {
+			continue
+		}
+		message := finding.Message
+		if finding.Detail != "" {
+			message += ": " + finding.Detail
+		}
+		return &ValidationError{Field: finding.Field, Message: message}
+	}
+	return nil
+}
+
+// ===== 内容检查 =====
+
+// LintContent 检查短信发送内容（SMSSend、SMSMultiSend 等的 content 参数，需包含签名）
+// content 应为已处理变量的正文（如 ProcessVariables 的结果），tag 为自定义标签（可为空）
+func (c *Client) LintContent(content, tag string) LintFindings {
+	var findings LintFindings
+
+	signature, body, found := splitSignature(content)
+	if !found {
+		findings = c.lintAppend(findings, ErrEmptyMessageSignature, "content", LintError, "")
+	} else {
+		findings = c.lintSignature(findings, "content", signature, ErrSignatureLengthInvalid, ErrSignatureLengthInvalid)
+	}
+
+	if strings.TrimSpace(body) == "" {
+		findings = c.lintAppend(findings, ErrEmptyContent, "content", LintError, "")
+	}
+	// 配置营销短信规则时按追加退订提示后的内容计算长度
+	sent := content
+	if c.marketing != nil {
+		if prepared, _ := c.PrepareContent(content); prepared.FooterAdded {
+			sent = prepared.Content
+		}
+	}
+	if n := utf8.RuneCountInString(sent); n > maxContentLength {
+		findings = c.lintAppend(findings, ErrContentTooLong, "content", LintError, strconv.Itoa(n))
+	}
+	findings = c.lintForbiddenWords(findings, "content", content)
+
+	for _, v := range unresolvedVarPattern.FindAllString(content, -1) {
+		findings = append(findings, LintFinding{
+			Field:    "content",
+			Severity: LintWarning,
+			Message:  localize(c.locale, msgUnresolvedVariable),
+			Detail:   v,
+		})
+	}
+
+	return c.lintTag(findings, tag)
+}
+
+// LintTemplate 检查短信模板（SMSTemplateCreate、SMSTemplateUpdate 的参数）
+// signature 为包含全角【】的短信签名，content 为模板正文（可包含 @var() 变量）
+func (c *Client) LintTemplate(title, signature, content string) LintFindings {
+	var findings LintFindings
+
+	name, ok := bracketedSignature(signature)
+	if !ok {
+		findings = c.lintAppend(findings, ErrMissingSignatureInTemplate, "sms_signature", LintError, signature)
+	} else {
+		findings = c.lintSignature(findings, "sms_signature", name, ErrSignatureLengthInvalid, ErrSignatureTooLongInTemplate)
+	}
+
+	if strings.TrimSpace(content) == "" {
+		findings = c.lintAppend(findings, ErrEmptyContentInTemplate, "sms_content", LintError, "")
+	}
+	if n := utf8.RuneCountInString(content); n > maxContentLength {
+		findings = c.lintAppend(findings, ErrContentTooLongInTemplate, "sms_content", LintError, strconv.Itoa(n))
+	}
+	if n := utf8.RuneCountInString(title); n > maxTitleLength {
+		findings = c.lintAppend(findings, ErrTitleTooLong, "sms_title", LintError, strconv.Itoa(n))
+	}
+
+	// 模板发送不会自动追加退订提示，营销类模板需在正文中包含
+	if c.marketing != nil && c.ClassifyContent(content) == MessageMarketing &&
+		!containsAny(content, c.marketing.optOutKeywords()) {
+		findings = append(findings, LintFinding{
+			Field:    "sms_content",
+			Severity: LintWarning,
+			Message:  localize(c.locale, msgOptOutMissing),
+			Detail:   c.marketing.footer(),
+		})
+	}
+
+	// 变量格式错误时模板可以提交，但发送时变量无法替换
+	for _, message := range c.ValidateVariables(content) {
+		findings = append(findings, LintFinding{Field: "sms_content", Severity: LintWarning, Message: message})
+	}
+
+	return c.lintForbiddenWords(findings, "sms_content", signature+content)
+}
+
+// lintSignature 检查签名字数（不含【】）
+func (c *Client) lintSignature(findings LintFindings, field, name string, tooShort, tooLong int) LintFindings {
+	n := utf8.RuneCountInString(name)
+	switch {
+	case n < minSignatureLength:
+		return c.lintAppend(findings, tooShort, field, LintError, name)
+	case n > maxSignatureLength:
+		return c.lintAppend(findings, tooLong, field, LintError, name)
+	}
+	return findings
+}
+
+// lintForbiddenWords 使用配置的禁用词词典检查
+func (c *Client) lintForbiddenWords(findings LintFindings, field, text string) LintFindings {
+	if c.forbiddenWords == nil {
+		return findings
+	}
+	for _, word := range c.forbiddenWords.Match(text) {
+		findings = c.lintAppend(findings, ErrForbiddenWords, field, LintError, word)
+	}
+	return findings
+}
+
+// lintTag 检查自定义标签长度
+func (c *Client) lintTag(findings LintFindings, tag string) LintFindings {
+	if n := utf8.RuneCountInString(tag); n > maxTagLength {
+		findings = c.lintAppend(findings, ErrTagTooLong, "tag", LintError, strconv.Itoa(n))
+	}
+	return findings
+}
+
+// lintAppend 追加使用API错误描述的检查结果
+func (c *Client) lintAppend(findings LintFindings, code int, field, severity, detail string) LintFindings {
+	return append(findings, LintFinding{
+		Code:     code,
+		Field:    field,
+		Severity: severity,
+		Message:  c.ErrorDescription(code),
+		Detail:   detail,
+	})
+}
+
+// splitSignature 从短信内容中拆分签名（位于开头或结尾的【】），返回不含括号的签名与正文
+func splitSignature(content string) (signature, body string, ok bool) {
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "【") {
+		if end := strings.Index(trimmed, "】"); end > 0 {
+			return trimmed[len("【"):end], trimmed[end+len("】"):], true
+		}
+	}
+	if strings.HasSuffix(trimmed, "】") {
+		if start := strings.LastIndex(trimmed, "【"); start >= 0 {
+			return trimmed[start+len("【") : len(trimmed)-len("】")], trimmed[:start], true
+		}
+	}
+	return "", trimmed, false
+}
+
+// bracketedSignature 获取【】中的签名，格式不正确时返回false
+func bracketedSignature(signature string) (string, bool) {
+	s := strings.TrimSpace(signature)
+	if !strings.HasPrefix(s, "【") || !strings.HasSuffix(s, "】") || len(s) < len("【】") {
+		return "", false
+	}
+	name := s[len("【") : len(s)-len("】")]
+	if strings.ContainsAny(name, "【】") {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,71 @@
+package submail_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+)
+
+func TestLintContent(t *testing.T) {
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", ForbiddenWords: submail.WordList{"赌博", "VPN"}})
+
+	tests := []struct {
+		name    string
+		content string
+		tag     string
+		codes   []int
+	}{
+		{"valid", "【测试】您的验证码是1234", "", nil},
+		{"signature at end", "您的验证码是1234【测试】", "", nil},
+		{"missing signature", "您的验证码是1234", "", []int{submail.ErrEmptyMessageSignature}},
+		{"signature too short", "【测】您好", "", []int{submail.ErrSignatureLengthInvalid}},
+		{"empty body", "【测试】", "", []int{submail.ErrEmptyContent}},
+		{"too long", "【测试】" + strings.Repeat("长", 1000), "", []int{submail.ErrContentTooLong}},
+		{"forbidden words", "【测试】免费vpn和赌博", "", []int{submail.ErrForbiddenWords, submail.ErrForbiddenWords}},
+		{"tag too long", "【测试】您好", strings.Repeat("t", 33), []int{submail.ErrTagTooLong}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := client.LintContent(tt.content, tt.tag)
+			if codes := findings.Codes(); !slices.Equal(codes, tt.codes) {
+				t.Fatalf("codes = %v, want %v (findings: %+v)", codes, tt.codes, findings)
+			}
+			if findings.HasErrors() != (len(tt.codes) > 0) {
+				t.Fatalf("HasErrors = %v", findings.HasErrors())
+			}
+		})
+	}
+}
+
+func TestLintContentWarnings(t *testing.T) {
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key"})
+
+	findings := client.LintContent("【测试】您好@var(name)", "")
+	if findings.HasErrors() || len(findings) != 1 || findings[0].Severity != submail.LintWarning || findings[0].Detail != "@var(name)" {
+		t.Fatalf("findings = %+v, want one unresolved variable warning", findings)
+	}
+	if findings.Err() != nil {
+		t.Fatalf("Err() = %v for warnings only", findings.Err())
+	}
+
+	err := client.LintContent("您好", "").Err()
+	if !submail.IsValidationError(err) {
+		t.Fatalf("Err() = %v, want *ValidationError", err)
+	}
+}
+
+func TestLintTemplate(t *testing.T) {
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key"})
+
+	if findings := client.LintTemplate("验证码", "【测试】", "您的验证码是@var(code)"); len(findings) != 0 {
+		t.Fatalf("valid template findings = %+v", findings)
+	}
+
+	findings := client.LintTemplate(strings.Repeat("标", 65), "测试", "")
+	want := []int{submail.ErrMissingSignatureInTemplate, submail.ErrEmptyContentInTemplate, submail.ErrTitleTooLong}
+	if codes := findings.Codes(); !slices.Equal(codes, want) {
+		t.Fatalf("codes = %v, want %v", codes, want)
+	}
+}
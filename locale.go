@@ -0,0 +1,664 @@
+package submail
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+// 语言设置
+const (
+	LocaleZhCN = "zh-CN" // 简体中文（默认）
+	LocaleEN   = "en"    // 英文
+)
+
+// 消息键
+const (
+	msgRequestNil           = "request_nil"
+	msgInvalidVariables     = "invalid_variables"
+	msgInvalidVariable      = "invalid_variable"
+	msgInvalidDateVariable  = "invalid_date_variable"
+	msgInvalidTimezone      = "invalid_timezone"
+	msgAttachmentsRequired  = "attachments_required"
+	msgSignatureRequired    = "signature_required"
+	msgSubhookURLRequired   = "subhook_url_required"
+	msgSubhookEventRequired = "subhook_event_required"
+	msgSubhookIDRequired    = "subhook_id_required"
+	msgInvalidEventTypes    = "invalid_event_types"
+	msgInvalidEventType     = "invalid_event_type"
+	msgTimestampRequest     = "timestamp_request"
+	msgTimestampAPIError    = "timestamp_api_error"
+	msgInvalidTimestamp     = "invalid_timestamp"
+	msgEmptyResponse        = "empty_response"
+	msgGetTimestamp         = "get_timestamp"
+	msgUnsupportedSignType  = "unsupported_sign_type"
+	msgUnsupportedMethod    = "unsupported_method"
+	msgBuildAuthParams      = "build_auth_params"
+	msgCreateRequest        = "create_request"
+	msgNoResponse           = "no_response"
+	msgRequestFailed        = "request_failed"
+	msgReadResponse         = "read_response"
+	msgHTTPError            = "http_error"
+	msgDecodeFailed         = "decode_failed"
+	msgResponseBody         = "response_body"
+	msgMarshalData          = "marshal_data"
+	msgUnmarshalData        = "unmarshal_data"
+	msgAddFormField         = "add_form_field"
+	msgCloseMultipart       = "close_multipart"
+	msgOpenFile             = "open_file"
+	msgCreateFileField      = "create_file_field"
+	msgWriteFile            = "write_file"
+	msgUnknownError         = "unknown_error"
+	msgUnknownEventType     = "unknown_event_type"
+	msgUnknownSourceType    = "unknown_source_type"
+	msgUnknownStatus        = "unknown_status"
+	msgRateLimited          = "rate_limited"
+	msgPhoneRateLimited     = "phone_rate_limited"
+	msgRateLimitStore       = "rate_limit_store"
+	msgCircuitOpen          = "circuit_open"
+	msgDryRunSink           = "dry_run_sink"
+	msgInvalidPhone         = "invalid_phone"
+	msgUnknownRouteTemplate = "unknown_route_template"
+	msgRouteContentRequired = "route_content_required"
+	msgInterContentRequired = "inter_content_required"
+	msgRouteNoResult        = "route_no_result"
+	msgUnresolvedVariable   = "unresolved_variable"
+	msgChunkFailed          = "chunk_failed"
+	msgChunksFailed         = "chunks_failed"
+	msgOutboxStatus         = "outbox_status"
+	msgOutboxNotPending     = "outbox_not_pending"
+	msgOutboxLeaseExpired   = "outbox_lease_expired"
+	msgUnsupportedEndpoint  = "unsupported_endpoint"
+	msgProjectRequired      = "project_required"
+	msgOTPCooldown          = "otp_cooldown"
+	msgOTPExpired           = "otp_expired"
+	msgOTPMismatch          = "otp_mismatch"
+	msgOTPAttemptsExceeded  = "otp_attempts_exceeded"
+	msgSuppressed           = "suppressed"
+	msgMarketingTooLong     = "marketing_too_long"
+	msgMarketingSegments    = "marketing_segments"
+	msgOptOutMissing        = "opt_out_missing"
+	msgUnlistedPhonePrefix  = "unlisted_phone_prefix"
+	msgSuppressionLearn     = "suppression_learn"
+	msgErrCircuitOpen       = "err_circuit_open"
+	msgErrRateLimited       = "err_rate_limited"
+	msgErrOutboxNotFound    = "err_outbox_not_found"
+	msgErrOTPCooldown       = "err_otp_cooldown"
+	msgErrOTPExpired        = "err_otp_expired"
+	msgErrOTPMismatch       = "err_otp_mismatch"
+	msgErrOTPAttempts       = "err_otp_attempts"
+	msgErrSuppressed        = "err_suppressed"
+	msgXMLDecodeTarget      = "xml_decode_target"
+	msgOutboxExists         = "outbox_exists"
+	msgOutboxDecodeVars     = "outbox_decode_vars"
+	msgOutboxCreateDir      = "outbox_create_dir"
+	msgOutboxOpenFile       = "outbox_open_file"
+	msgOutboxWriteFile      = "outbox_write_file"
+	msgOutboxReadFile       = "outbox_read_file"
+	msgOutboxCompact        = "outbox_compact"
+	msgOutboxCorruptLine    = "outbox_corrupt_line"
+
+	// 响应解析目标
+	targetTimestamp       = "target_timestamp"
+	targetServiceStatus   = "target_service_status"
+	targetSMSSend         = "target_sms_send"
+	targetSMSXSend        = "target_sms_xsend"
+	targetSMSMultiSend    = "target_sms_multisend"
+	targetSMSMultiXSend   = "target_sms_multixsend"
+	targetSMSBatchSend    = "target_sms_batchsend"
+	targetSMSBatchXSend   = "target_sms_batchxsend"
+	targetSMSUnionSend    = "target_sms_unionsend"
+	targetSignatureQuery  = "target_signature_query"
+	targetSignatureCreate = "target_signature_create"
+	targetSignatureUpdate = "target_signature_update"
+	targetSignatureDelete = "target_signature_delete"
+	targetTemplateGet     = "target_template_get"
+	targetTemplateCreate  = "target_template_create"
+	targetTemplateUpdate  = "target_template_update"
+	targetTemplateDelete  = "target_template_delete"
+	targetSMSReports      = "target_sms_reports"
+	targetSMSBalance      = "target_sms_balance"
+	targetSMSBalanceLog   = "target_sms_balancelog"
+	targetSMSLog          = "target_sms_log"
+	targetSMSMO           = "target_sms_mo"
+	targetSubhookCreate   = "target_subhook_create"
+	targetSubhookQuery    = "target_subhook_query"
+	targetSubhookDelete   = "target_subhook_delete"
+)
+
+// messageCatalog SDK消息目录（按语言区分）
+var messageCatalog = map[string]map[string]string{
+	LocaleZhCN: {
+		msgRequestNil:           "请求参数不能为空",
+		msgInvalidVariables:     "变量格式错误: %v",
+		msgInvalidVariable:      "无效的变量格式: %s",
+		msgInvalidDateVariable:  "无效的日期变量格式: %s",
+		msgInvalidTimezone:      "无效的时区: %w",
+		msgAttachmentsRequired:  "必须提供证明材料文件",
+		msgSignatureRequired:    "短信签名不能为空",
+		msgSubhookURLRequired:   "回调URL不能为空",
+		msgSubhookEventRequired: "事件类型不能为空",
+		msgSubhookIDRequired:    "SUBHOOK ID不能为空",
+		msgInvalidEventTypes:    "事件类型验证失败: %v",
+		msgInvalidEventType:     "无效的事件类型: %s",
+		msgTimestampRequest:     "请求时间戳API失败: %w",
+		msgTimestampAPIError:    "时间戳API返回错误: %w",
+		msgInvalidTimestamp:     "无效的时间戳: %d",
+		msgEmptyResponse:        "响应为空",
+		msgGetTimestamp:         "获取时间戳失败: %w",
+		msgUnsupportedSignType:  "不支持的签名类型: %s",
+		msgUnsupportedMethod:    "不支持的HTTP方法: %s",
+		msgBuildAuthParams:      "构建认证参数失败: %w",
+		msgCreateRequest:        "创建请求失败: %w",
+		msgNoResponse:           "拦截器未返回响应: %s",
+		msgRequestFailed:        "执行请求失败",
+		msgReadResponse:         "读取响应失败",
+		msgHTTPError:            "HTTP错误: %d - %s",
+		msgDecodeFailed:         "解析%s失败",
+		msgResponseBody:         "响应内容: %s",
+		msgMarshalData:          "序列化请求数据失败: %w",
+		msgUnmarshalData:        "解析请求数据失败: %w",
+		msgAddFormField:         "添加表单字段失败: %w",
+		msgCloseMultipart:       "关闭multipart writer失败: %w",
+		msgOpenFile:             "打开文件失败: %w",
+		msgCreateFileField:      "创建文件字段失败: %w",
+		msgWriteFile:            "写入文件数据失败: %w",
+		msgUnknownError:         "未知错误",
+		msgUnknownEventType:     "未知事件类型",
+		msgUnknownSourceType:    "未知类型",
+		msgUnknownStatus:        "未知状态",
+		msgRateLimited:          "超出全局请求频率限制，请在 %v 后重试",
+		msgPhoneRateLimited:     "手机号 %s 超出发送频率限制，请在 %v 后重试",
+		msgRateLimitStore:       "限流存储错误: %w",
+		msgCircuitOpen:          "%s 分组的所有API地址均已熔断，请稍后重试",
+		msgDryRunSink:           "写入演练记录失败: %w",
+		msgInvalidPhone:         "无效的手机号码: %q",
+		msgUnknownRouteTemplate: "未知的路由模板: %s",
+		msgRouteContentRequired: "路由模板必须设置模板ID或短信正文",
+		msgInterContentRequired: "未设置国际短信正文，无法发送到国际号码",
+		msgRouteNoResult:        "发送响应中没有号码 %s 的结果",
+		msgUnresolvedVariable:   "短信内容包含未替换的变量",
+		msgChunkFailed:          "第%d批（第%d至%d个收件人）发送失败: %v",
+		msgChunksFailed:         "%d/%d 个批次发送失败，首个错误: %v",
+		msgOutboxStatus:         "发件箱消息 %s 的状态为 %s，只能重新发送死信",
+		msgOutboxNotPending:     "发件箱消息 %s 的状态为 %s，只能取消待发送的消息",
+		msgOutboxLeaseExpired:   "发送过程中断，无法确定短信是否已发送",
+		msgUnsupportedEndpoint:  "不支持的发送接口: %s",
+		msgProjectRequired:      "短信模板ID不能为空",
+		msgOTPCooldown:          "验证码发送过于频繁，请在 %v 后重试",
+		msgOTPExpired:           "验证码不存在或已过期，请重新获取",
+		msgOTPMismatch:          "验证码错误，还可尝试 %d 次",
+		msgOTPAttemptsExceeded:  "验证码错误次数过多，请重新获取",
+		msgSuppressed:           "手机号 %s 在屏蔽名单中（%s），已跳过发送",
+		msgMarketingTooLong:     "营销短信追加退订提示后长度为 %d 字，超过 %d 字上限",
+		msgMarketingSegments:    "营销短信追加退订提示后为 %d 条计费，超过 %d 条上限",
+		msgOptOutMissing:        "营销类模板缺少退订提示，模板发送时不会自动追加",
+		msgUnlistedPhonePrefix:  "手机号码 %s 的号段 %s 未收录，已按原样发送，由服务器判断是否有效",
+		msgSuppressionLearn:     "%d 个号码加入屏蔽名单失败: %v",
+		msgErrCircuitOpen:       "所有API地址均已熔断",
+		msgErrRateLimited:       "超出发送频率限制",
+		msgErrOutboxNotFound:    "发件箱消息不存在",
+		msgErrOTPCooldown:       "验证码发送过于频繁",
+		msgErrOTPExpired:        "验证码不存在或已过期",
+		msgErrOTPMismatch:       "验证码错误",
+		msgErrOTPAttempts:       "验证码错误次数过多",
+		msgErrSuppressed:        "收件人在屏蔽名单中",
+		msgXMLDecodeTarget:      "XML解码目标必须为非空指针",
+		msgOutboxExists:         "发件箱消息已存在: %s",
+		msgOutboxDecodeVars:     "解析发件箱消息变量失败: %w",
+		msgOutboxCreateDir:      "创建发件箱目录失败: %w",
+		msgOutboxOpenFile:       "打开发件箱文件失败: %w",
+		msgOutboxWriteFile:      "写入发件箱文件失败: %w",
+		msgOutboxReadFile:       "读取发件箱文件失败: %w",
+		msgOutboxCompact:        "压缩发件箱文件失败: %w",
+		msgOutboxCorruptLine:    "发件箱文件第 %d 行损坏: %w",
+
+		targetTimestamp:       "时间戳响应",
+		targetServiceStatus:   "服务状态响应",
+		targetSMSSend:         "短信发送响应",
+		targetSMSXSend:        "短信模板发送响应",
+		targetSMSMultiSend:    "短信一对多发送响应",
+		targetSMSMultiXSend:   "短信模板一对多发送响应",
+		targetSMSBatchSend:    "短信批量群发响应",
+		targetSMSBatchXSend:   "短信批量模板群发响应",
+		targetSMSUnionSend:    "短信联合发送响应",
+		targetSignatureQuery:  "短信签名查询响应",
+		targetSignatureCreate: "短信签名创建响应",
+		targetSignatureUpdate: "短信签名更新响应",
+		targetSignatureDelete: "短信签名删除响应",
+		targetTemplateGet:     "短信模板查询响应",
+		targetTemplateCreate:  "短信模板创建响应",
+		targetTemplateUpdate:  "短信模板更新响应",
+		targetTemplateDelete:  "短信模板删除响应",
+		targetSMSReports:      "短信分析报告响应",
+		targetSMSBalance:      "短信余额响应",
+		targetSMSBalanceLog:   "短信余额日志响应",
+		targetSMSLog:          "短信历史明细响应",
+		targetSMSMO:           "短信上行查询响应",
+		targetSubhookCreate:   "SUBHOOK 创建响应",
+		targetSubhookQuery:    "SUBHOOK 查询响应",
+		targetSubhookDelete:   "SUBHOOK 删除响应",
+	},
+	LocaleEN: {
+		msgRequestNil:           "request must not be nil",
+		msgInvalidVariables:     "invalid variables: %v",
+		msgInvalidVariable:      "invalid variable: %s",
+		msgInvalidDateVariable:  "invalid date variable: %s",
+		msgInvalidTimezone:      "invalid timezone: %w",
+		msgAttachmentsRequired:  "attachments (supporting documents) are required",
+		msgSignatureRequired:    "sms_signature must not be empty",
+		msgSubhookURLRequired:   "callback url must not be empty",
+		msgSubhookEventRequired: "event types must not be empty",
+		msgSubhookIDRequired:    "SUBHOOK id (target) must not be empty",
+		msgInvalidEventTypes:    "invalid event types: %v",
+		msgInvalidEventType:     "invalid event type: %s",
+		msgTimestampRequest:     "timestamp API request failed: %w",
+		msgTimestampAPIError:    "timestamp API returned an error: %w",
+		msgInvalidTimestamp:     "invalid timestamp: %d",
+		msgEmptyResponse:        "empty response",
+		msgGetTimestamp:         "failed to get timestamp: %w",
+		msgUnsupportedSignType:  "unsupported sign type: %s",
+		msgUnsupportedMethod:    "unsupported HTTP method: %s",
+		msgBuildAuthParams:      "failed to build auth params: %w",
+		msgCreateRequest:        "failed to create request: %w",
+		msgNoResponse:           "interceptor returned no response: %s",
+		msgRequestFailed:        "request failed",
+		msgReadResponse:         "failed to read response",
+		msgHTTPError:            "HTTP error: %d - %s",
+		msgDecodeFailed:         "failed to decode %s",
+		msgResponseBody:         "response body: %s",
+		msgMarshalData:          "failed to marshal request data: %w",
+		msgUnmarshalData:        "failed to unmarshal request data: %w",
+		msgAddFormField:         "failed to add form field: %w",
+		msgCloseMultipart:       "failed to close multipart writer: %w",
+		msgOpenFile:             "failed to open file: %w",
+		msgCreateFileField:      "failed to create file field: %w",
+		msgWriteFile:            "failed to write file data: %w",
+		msgUnknownError:         "unknown error",
+		msgUnknownEventType:     "unknown event type",
+		msgUnknownSourceType:    "unknown type",
+		msgUnknownStatus:        "unknown status",
+		msgRateLimited:          "global request rate limit exceeded, retry after %v",
+		msgPhoneRateLimited:     "send rate limit exceeded for phone %s, retry after %v",
+		msgRateLimitStore:       "rate limit store error: %w",
+		msgCircuitOpen:          "all endpoints in the %s group are unavailable (circuit open), retry later",
+		msgDryRunSink:           "failed to write dry-run message: %w",
+		msgInvalidPhone:         "invalid phone number: %q",
+		msgUnknownRouteTemplate: "unknown route template: %s",
+		msgRouteContentRequired: "route template requires a project or content",
+		msgInterContentRequired: "international content is not set, cannot send to international numbers",
+		msgRouteNoResult:        "no result for %s in the send response",
+		msgUnresolvedVariable:   "content contains an unresolved variable",
+		msgChunkFailed:          "chunk %d (recipients %d-%d) failed: %v",
+		msgChunksFailed:         "%d/%d chunks failed, first error: %v",
+		msgOutboxStatus:         "outbox message %s is %s, only dead messages can be requeued",
+		msgOutboxNotPending:     "outbox message %s is %s, only pending messages can be canceled",
+		msgOutboxLeaseExpired:   "sending was interrupted, the message may or may not have been sent",
+		msgUnsupportedEndpoint:  "unsupported send endpoint: %s",
+		msgProjectRequired:      "template ID is required",
+		msgOTPCooldown:          "verification code requested too frequently, retry after %v",
+		msgOTPExpired:           "verification code not found or expired, please request a new one",
+		msgOTPMismatch:          "incorrect verification code, %d attempts remaining",
+		msgOTPAttemptsExceeded:  "too many incorrect attempts, please request a new verification code",
+		msgSuppressed:           "phone %s is on the suppression list (%s), skipped",
+		msgMarketingTooLong:     "marketing content with opt-out footer is %d characters, exceeding the %d limit",
+		msgMarketingSegments:    "marketing content with opt-out footer is %d segments, exceeding the %d limit",
+		msgOptOutMissing:        "marketing template has no opt-out instruction, template sends do not append one",
+		msgUnlistedPhonePrefix:  "phone number %s has an unlisted prefix %s, sent as-is for the server to validate",
+		msgSuppressionLearn:     "failed to add %d phone numbers to the suppression list: %v",
+		msgErrCircuitOpen:       "all endpoints are unavailable (circuit open)",
+		msgErrRateLimited:       "send rate limit exceeded",
+		msgErrOutboxNotFound:    "outbox message not found",
+		msgErrOTPCooldown:       "verification code requested too frequently",
+		msgErrOTPExpired:        "verification code not found or expired",
+		msgErrOTPMismatch:       "incorrect verification code",
+		msgErrOTPAttempts:       "too many incorrect verification attempts",
+		msgErrSuppressed:        "recipient is on the suppression list",
+		msgXMLDecodeTarget:      "XML decode target must be a non-nil pointer",
+		msgOutboxExists:         "outbox message already exists: %s",
+		msgOutboxDecodeVars:     "failed to decode outbox message vars: %w",
+		msgOutboxCreateDir:      "failed to create outbox directory: %w",
+		msgOutboxOpenFile:       "failed to open outbox file: %w",
+		msgOutboxWriteFile:      "failed to write outbox file: %w",
+		msgOutboxReadFile:       "failed to read outbox file: %w",
+		msgOutboxCompact:        "failed to compact outbox file: %w",
+		msgOutboxCorruptLine:    "outbox file line %d is corrupt: %w",
+
+		targetTimestamp:       "timestamp response",
+		targetServiceStatus:   "service status response",
+		targetSMSSend:         "SMS send response",
+		targetSMSXSend:        "SMS template send response",
+		targetSMSMultiSend:    "SMS multisend response",
+		targetSMSMultiXSend:   "SMS template multisend response",
+		targetSMSBatchSend:    "SMS batch send response",
+		targetSMSBatchXSend:   "SMS batch template send response",
+		targetSMSUnionSend:    "SMS union send response",
+		targetSignatureQuery:  "SMS signature query response",
+		targetSignatureCreate: "SMS signature create response",
+		targetSignatureUpdate: "SMS signature update response",
+		targetSignatureDelete: "SMS signature delete response",
+		targetTemplateGet:     "SMS template query response",
+		targetTemplateCreate:  "SMS template create response",
+		targetTemplateUpdate:  "SMS template update response",
+		targetTemplateDelete:  "SMS template delete response",
+		targetSMSReports:      "SMS reports response",
+		targetSMSBalance:      "SMS balance response",
+		targetSMSBalanceLog:   "SMS balance log response",
+		targetSMSLog:          "SMS log response",
+		targetSMSMO:           "SMS MO response",
+		targetSubhookCreate:   "SUBHOOK create response",
+		targetSubhookQuery:    "SUBHOOK query response",
+		targetSubhookDelete:   "SUBHOOK delete response",
+	},
+}
+
+// ErrorMessagesEN 英文错误信息映射（中文见 ErrorMessages）
+var ErrorMessagesEN = map[int]string{
+	ErrIncorrectAppID:               "Incorrect APP ID",
+	ErrAppDisabled:                  "This app has been disabled; enable it at submail > App Integration > Apps",
+	ErrDeveloperNotAvailable:        "Developer not enabled; the developer identity of this app is not verified, please update your developer profile",
+	ErrDeveloperNotVerified:         "Developer not verified or developer profile changed; please update your developer profile on the App Integration page",
+	ErrAccountExpired:               "This account has expired",
+	ErrAccountDisabled:              "This account has been disabled",
+	ErrInvalidSignType:              "sign_type must be MD5 (MD5 signature), SHA1 (SHA1 signature) or normal (plain key)",
+	ErrInvalidSignature:             "Invalid signature parameter",
+	ErrInvalidAppKey:                "Invalid appkey",
+	ErrWrongSignType:                "Wrong sign_type",
+	ErrEmptySignatureParam:          "Empty signature parameter",
+	ErrSubscriptionDisabled:         "Subscribe/unsubscribe is disabled for this app",
+	ErrIPNotInWhitelist:             "This APPID has an IP whitelist and your IP is not in it",
+	ErrPhoneInBlacklist:             "This phone number is in the account blacklist and has been blocked",
+	ErrPhoneFrequencyLimit:          "Request limit exceeded for this phone number",
+	ErrSignatureUsedByOther:         "Signature error: this signature is used by another app with a fixed signature",
+	ErrTemplateSignatureInconsist:   "Template is invalid: template signature differs from the fixed signature, or fixed signature was cancelled; contact SUBMAIL support",
+	ErrTemplateInvalid:              "Template is invalid; contact SUBMAIL support",
+	ErrPermissionDenied:             "You are not permitted to use this API; contact SUBMAIL support",
+	ErrTemplateExpired:              "Template is invalid",
+	ErrSignatureNotReported:         "SMS signature has not been registered yet",
+	ErrSignatureAlreadyExists:       "SMS signature already exists; no need to create it",
+	ErrTimestampError:               "Incorrect UNIX timestamp",
+	ErrInvalidTimestamp:             "Incorrect UNIX timestamp; the time between fetching the timestamp and calling the API must be within 6 seconds",
+	ErrNoAvailableSignature:         "No available signature under this appid",
+	ErrUnknownAddressbookModel:      "Unknown addressbook mode",
+	ErrIncorrectEmailAddress:        "Incorrect recipient address",
+	ErrEmptyAddressbook:             "Incorrect recipient address; the addressbook you specified contains no contacts",
+	ErrIncorrectMessageAddress:      "Incorrect recipient address (message)",
+	ErrEmptyMessageAddressbook:      "Incorrect recipient address (message); the addressbook you specified contains no contacts",
+	ErrContactUnsubscribed:          "This contact has unsubscribed from your SMS",
+	ErrEmptyProjectID:               "Project ID is missing",
+	ErrInvalidProjectID:             "Invalid project ID",
+	ErrIncorrectJSON:                "Malformed JSON; please check the vars and links parameters",
+	ErrTagTooLong:                   "tag must not exceed 32 characters",
+	ErrEmptyMessageSignature:        "SMS signature must not be empty",
+	ErrSignatureTooLong:             "SMS signature must be within 40 characters",
+	ErrEmptyContent:                 "SMS content must not be empty",
+	ErrContentTooLong:               "SMS content (including signature) must be within 1000 characters",
+	ErrForbiddenWords:               "Under local laws and regulations, the following words or phrases may not appear in SMS",
+	ErrEmptyProjectIDForContent:     "Project ID must not be empty",
+	ErrInvalidProjectIDForContent:   "Invalid project ID",
+	ErrDuplicateMessage:             "You cannot send identical SMS to this contact or to contacts in this addressbook",
+	ErrMessageUnderReview:           "The SMS project is under review; please try again later",
+	ErrInvalidMultiParam:            "Invalid multi parameter",
+	ErrMissingSignatureInTemplate:   "Each SMS template needs a signature enclosed in full-width brackets 【 and 】, 2 to 10 characters long (brackets excluded)",
+	ErrSignatureTooLongInTemplate:   "SMS signature must be within 10 characters (brackets excluded)",
+	ErrSignatureLengthInvalid:       "SMS signature must be between 2 and 10 characters (brackets excluded)",
+	ErrEmptyContentInTemplate:       "Please submit the SMS content",
+	ErrContentTooLongInTemplate:     "SMS content must be within 1000 characters",
+	ErrTitleTooLong:                 "SMS title must be within 64 characters",
+	ErrEmptyTemplateID:              "Please submit the ID of the template to update",
+	ErrTemplateNotExists:            "The template to update does not exist",
+	ErrEmptyContentForUpdate:        "SMS content must not be empty",
+	ErrNoMatchingTemplate:           "No matching template found",
+	ErrTemplateTooLong:              "Template must be within 255 characters",
+	ErrInvalidAddressbookSign:       "Incorrect target addressbook identifier",
+	ErrQuotaExhausted:               "Today's sending quota is used up; enable more quota at submail > App Integration > Apps",
+	ErrInsufficientCredit:           "SMS credits are used up or insufficient for this request; buy more at submail.cn > Store and retry",
+	ErrInsufficientBalance:          "Account balance is used up or insufficient for this request; top up at submail.cn > Store and retry",
+	ErrInsufficientTransactionalSMS: "Transactional SMS balance is used up or insufficient for this request; top up at submail.cn > Store and retry",
+}
+
+// eventTypeDescriptions 事件类型描述
+var eventTypeDescriptions = map[string]map[string]string{
+	LocaleZhCN: {
+		SubhookEventRequest:        "发送请求被接收",
+		SubhookEventDelivered:      "发送成功",
+		SubhookEventDropped:        "发送失败",
+		SubhookEventSending:        "正在发送",
+		SubhookEventMO:             "短信上行（用户回复）",
+		SubhookEventTemplateAccept: "短信模板审核通过",
+		SubhookEventTemplateReject: "短信模板审核未通过",
+	},
+	LocaleEN: {
+		SubhookEventRequest:        "Request accepted",
+		SubhookEventDelivered:      "Delivered",
+		SubhookEventDropped:        "Dropped",
+		SubhookEventSending:        "Sending",
+		SubhookEventMO:             "Mobile originated (user reply)",
+		SubhookEventTemplateAccept: "SMS template approved",
+		SubhookEventTemplateReject: "SMS template rejected",
+	},
+}
+
+// signatureStatuses 签名审核状态描述（0审核中，1审核通过，其他为审核不通过）
+var signatureStatuses = map[string][]string{
+	LocaleZhCN: {"审核中", "审核通过", "审核不通过"},
+	LocaleEN:   {"Under review", "Approved", "Rejected"},
+}
+
+// sourceTypeDescriptions 证明材料类型描述（0营业执照，1商标，2APP）
+var sourceTypeDescriptions = map[string][]string{
+	LocaleZhCN: {"营业执照", "商标", "APP"},
+	LocaleEN:   {"Business license", "Trademark", "APP"},
+}
+
+// templateStatuses 模板审核状态描述
+var templateStatuses = map[string]map[string]string{
+	LocaleZhCN: {"0": "未提交审核", "1": "正在审核", "2": "审核通过", "3": "未通过审核"},
+	LocaleEN:   {"0": "Not submitted", "1": "Under review", "2": "Approved", "3": "Rejected"},
+}
+
+// phoneReasons 号码无效原因（phone.ParseError.Reason）的描述，%s 为 Detail，空键为 phone.ErrInvalidNumber 的信息
+var phoneReasons = map[string]map[string]string{
+	LocaleZhCN: {
+		"":                              "无效的手机号码",
+		phone.ReasonEmpty:               "号码为空",
+		phone.ReasonInvalidCharacter:    "包含无效字符 %s",
+		phone.ReasonNotMainland:         "不是中国大陆手机号，国际号码需以 + 或 00 开头",
+		phone.ReasonInternationalLength: "国际号码长度应为8到15位",
+		phone.ReasonCountryCode:         "国际电话区号不能以0开头",
+		phone.ReasonTooShort:            "号码长度不足",
+		phone.ReasonMainlandLength:      "中国大陆手机号应为以1开头的11位号码",
+		phone.ReasonUnknownPrefix:       "号段 %s 不是有效的中国大陆手机号段",
+	},
+	LocaleEN: {
+		"":                              "invalid phone number",
+		phone.ReasonEmpty:               "number is empty",
+		phone.ReasonInvalidCharacter:    "contains invalid character %s",
+		phone.ReasonNotMainland:         "not a mainland China mobile number, international numbers must start with + or 00",
+		phone.ReasonInternationalLength: "international numbers must have 8 to 15 digits",
+		phone.ReasonCountryCode:         "country code cannot start with 0",
+		phone.ReasonTooShort:            "number is too short",
+		phone.ReasonMainlandLength:      "mainland China mobile numbers must have 11 digits starting with 1",
+		phone.ReasonUnknownPrefix:       "%s is not a mainland China mobile prefix",
+	},
+}
+
+func init() {
+	// phone 包的错误信息使用默认语言
+	phone.SetLocalizer(func(e *phone.ParseError) string {
+		return localizeParseError(DefaultLocale(), e)
+	})
+}
+
+// localizeParseError 生成指定语言的号码解析错误信息
+func localizeParseError(locale string, e *phone.ParseError) string {
+	reasons := phoneReasons[normalizeLocale(locale)]
+	reason, ok := reasons[e.Reason]
+	if !ok {
+		reason = e.Reason
+	}
+	if strings.Contains(reason, "%s") {
+		reason = fmt.Sprintf(reason, e.Detail)
+	}
+
+	switch {
+	case e.Reason == "":
+		return reason
+	case e.Number == "":
+		return reasons[""] + ": " + reason
+	}
+	return reasons[""] + " " + e.Number + ": " + reason
+}
+
+// defaultLocale 默认语言（规范化后的值）
+var defaultLocale atomic.Value
+
+// SetDefaultLocale 设置默认语言（默认简体中文）
+// 未设置 Config.Locale 的客户端，以及哨兵错误（如 ErrTransport）、发件箱存储等不关联客户端的错误信息使用该语言
+func SetDefaultLocale(locale string) {
+	if locale == "" {
+		locale = LocaleZhCN
+	}
+	defaultLocale.Store(normalizeLocale(locale))
+}
+
+// DefaultLocale 获取默认语言
+func DefaultLocale() string {
+	if locale, ok := defaultLocale.Load().(string); ok {
+		return locale
+	}
+	return LocaleZhCN
+}
+
+// normalizeLocale 规范化语言设置，支持 zh、zh-CN、zh_CN、en、en-US 等写法，为空时使用默认语言，未知语言使用简体中文
+func normalizeLocale(locale string) string {
+	if locale == "" {
+		return DefaultLocale()
+	}
+	if strings.HasPrefix(strings.ToLower(locale), "en") {
+		return LocaleEN
+	}
+	return LocaleZhCN
+}
+
+// sentinelError 可使用 errors.Is 判断的哨兵错误，错误信息为消息目录中默认语言的文本
+type sentinelError string
+
+func (e sentinelError) Error() string {
+	return localize(DefaultLocale(), string(e))
+}
+
+// localize 获取指定语言的消息文本，缺失时回退到简体中文
+func localize(locale, key string) string {
+	if msg, ok := messageCatalog[normalizeLocale(locale)][key]; ok {
+		return msg
+	}
+	if msg, ok := messageCatalog[LocaleZhCN][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// localizef 获取指定语言的格式化消息文本
+func localizef(locale, key string, args ...interface{}) string {
+	return fmt.Sprintf(localize(locale, key), args...)
+}
+
+// localizedError 创建指定语言的错误（消息中的 %w 会保留错误链）
+func localizedError(locale, key string, args ...interface{}) error {
+	return fmt.Errorf(localize(locale, key), args...)
+}
+
+// GetErrorDescription 获取指定语言的API错误描述
+func GetErrorDescription(locale string, code int) string {
+	messages := ErrorMessages
+	if normalizeLocale(locale) == LocaleEN {
+		messages = ErrorMessagesEN
+	}
+	if description, ok := messages[code]; ok {
+		return description
+	}
+	return localize(locale, msgUnknownError)
+}
+
+// GetEventTypeDescriptionWithLocale 获取指定语言的事件类型描述
+func GetEventTypeDescriptionWithLocale(locale, eventType string) string {
+	if desc, ok := eventTypeDescriptions[normalizeLocale(locale)][eventType]; ok {
+		return desc
+	}
+	return localize(locale, msgUnknownEventType)
+}
+
+// GetSignatureStatusWithLocale 获取指定语言的签名状态描述
+func GetSignatureStatusWithLocale(locale string, status int) string {
+	statuses := signatureStatuses[normalizeLocale(locale)]
+	switch status {
+	case 0, 1:
+		return statuses[status]
+	default:
+		return statuses[2]
+	}
+}
+
+// GetSourceTypeDescriptionWithLocale 获取指定语言的材料类型描述
+func GetSourceTypeDescriptionWithLocale(locale string, sourceType int) string {
+	types := sourceTypeDescriptions[normalizeLocale(locale)]
+	if sourceType >= 0 && sourceType < len(types) {
+		return types[sourceType]
+	}
+	return localize(locale, msgUnknownSourceType)
+}
+
+// GetTemplateStatusWithLocale 获取指定语言的模板状态描述
+func GetTemplateStatusWithLocale(locale, status string) string {
+	if desc, ok := templateStatuses[normalizeLocale(locale)][status]; ok {
+		return desc
+	}
+	return localize(locale, msgUnknownStatus)
+}
+
+// ===== 客户端语言方法 =====
+
+// Locale 获取客户端使用的语言
+func (c *Client) Locale() string {
+	return c.locale
+}
+
+// ErrorDescription 获取API错误码的描述（使用客户端语言）
+func (c *Client) ErrorDescription(code int) string {
+	return GetErrorDescription(c.locale, code)
+}
+
+// EventTypeDescription 获取事件类型描述（使用客户端语言）
+func (c *Client) EventTypeDescription(eventType string) string {
+	return GetEventTypeDescriptionWithLocale(c.locale, eventType)
+}
+
+// SignatureStatus 获取签名状态描述（使用客户端语言）
+func (c *Client) SignatureStatus(status int) string {
+	return GetSignatureStatusWithLocale(c.locale, status)
+}
+
+// SourceTypeDescription 获取材料类型描述（使用客户端语言）
+func (c *Client) SourceTypeDescription(sourceType int) string {
+	return GetSourceTypeDescriptionWithLocale(c.locale, sourceType)
+}
+
+// TemplateStatus 获取模板状态描述（使用客户端语言）
+func (c *Client) TemplateStatus(status string) string {
+	return GetTemplateStatusWithLocale(c.locale, status)
+}
+
+// errorf 创建使用客户端语言的错误
+func (c *Client) errorf(key string, args ...interface{}) error {
+	return localizedError(c.locale, key, args...)
+}
+
+// localizeAPIError 将API错误描述替换为客户端语言
+func (c *Client) localizeAPIError(err error) {
+	if apiErr, ok := AsAPIError(err); ok {
+		apiErr.Description = GetErrorDescription(c.locale, apiErr.Code)
+	}
+}
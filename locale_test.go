@@ -0,0 +1,88 @@
+package submail
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+func TestMessageCatalogComplete(t *testing.T) {
+	zh, en := messageCatalog[LocaleZhCN], messageCatalog[LocaleEN]
+	for key := range zh {
+		if _, ok := en[key]; !ok {
+			t.Errorf("message %q has no English translation", key)
+		}
+	}
+	for key := range en {
+		if _, ok := zh[key]; !ok {
+			t.Errorf("message %q has no Chinese translation", key)
+		}
+	}
+	for reason := range phoneReasons[LocaleZhCN] {
+		if _, ok := phoneReasons[LocaleEN][reason]; !ok {
+			t.Errorf("phone reason %q has no English translation", reason)
+		}
+	}
+	for code := range ErrorMessages {
+		if _, ok := ErrorMessagesEN[code]; !ok {
+			t.Errorf("error code %d has no English description", code)
+		}
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := map[string]string{
+		"en": LocaleEN, "en-US": LocaleEN, "EN_us": LocaleEN,
+		"zh": LocaleZhCN, "zh_CN": LocaleZhCN, "fr": LocaleZhCN, "": LocaleZhCN,
+	}
+	for in, want := range tests {
+		if got := normalizeLocale(in); got != want {
+			t.Errorf("normalizeLocale(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClientLocale(t *testing.T) {
+	client := NewClient(Config{AppID: "test-app", AppKey: "test-key", Locale: "en-US"})
+	if client.Locale() != LocaleEN {
+		t.Fatalf("Locale() = %q, want en", client.Locale())
+	}
+
+	_, err := client.SMSSend(nil)
+	if err == nil || err.Error() != messageCatalog[LocaleEN][msgRequestNil] {
+		t.Fatalf("err = %v, want English message", err)
+	}
+	if got := GetErrorDescription(LocaleEN, ErrIncorrectAppID); got != ErrorMessagesEN[ErrIncorrectAppID] {
+		t.Fatalf("GetErrorDescription = %q", got)
+	}
+	if got := GetTemplateStatusWithLocale(LocaleEN, "2"); got != "Approved" {
+		t.Fatalf("GetTemplateStatusWithLocale = %q", got)
+	}
+}
+
+func TestSetDefaultLocale(t *testing.T) {
+	defer SetDefaultLocale(LocaleZhCN)
+
+	SetDefaultLocale(LocaleEN)
+	if DefaultLocale() != LocaleEN {
+		t.Fatalf("DefaultLocale() = %q", DefaultLocale())
+	}
+	if got := ErrTransport.Error(); got != messageCatalog[LocaleEN][msgRequestFailed] {
+		t.Fatalf("ErrTransport = %q, want English message", got)
+	}
+	if client := NewClient(Config{AppID: "test-app", AppKey: "test-key"}); client.Locale() != LocaleEN {
+		t.Fatalf("client locale = %q, want default en", client.Locale())
+	}
+
+	_, err := phone.Parse("+0123456789")
+	if got := err.Error(); got != "invalid phone number +0123456789: country code cannot start with 0" {
+		t.Fatalf("phone error = %q, want English message", got)
+	}
+
+	// 哨兵错误的文本随默认语言变化，但 errors.Is 的判断不受影响
+	wrapped := &transportError{locale: LocaleZhCN, err: errors.New("boom")}
+	if !errors.Is(wrapped, ErrTransport) {
+		t.Fatal("transport error no longer matches ErrTransport")
+	}
+}
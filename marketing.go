@@ -0,0 +1,193 @@
+package submail
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 短信类型（与 SMSBalanceResponse 中的两类余额对应）
+const (
+	MessageTransactional = "transactional" // 事务类（验证码、通知等），扣除 TransactionalBalance
+	MessageMarketing     = "marketing"     // 营销类（促销、会员活动等），扣除 Balance
+)
+
+// DefaultOptOutFooter 默认的退订提示
+const DefaultOptOutFooter = "回T退订"
+
+// defaultMarketingKeywords 默认的营销类关键词
+var defaultMarketingKeywords = []string{
+	"优惠", "促销", "折扣", "打折", "特价", "秒杀", "抢购", "限时", "满减", "立减", "返现", "红包",
+	"领券", "优惠券", "代金券", "积分兑换", "会员日", "大促", "清仓", "包邮", "上新", "新品", "福利", "好礼",
+	"团购", "拼团", "活动", "专享", "低至", "免费领",
+}
+
+// defaultTransactionalKeywords 默认的事务类关键词，优先于营销类关键词
+var defaultTransactionalKeywords = []string{"验证码", "校验码", "动态码", "动态密码"}
+
+// defaultOptOutKeywords 默认的退订提示关键词，内容包含任一关键词即视为已有退订提示
+var defaultOptOutKeywords = []string{"退订", "拒收"}
+
+// MessageClassifier 短信分类器
+type MessageClassifier interface {
+	// Classify 返回 MessageTransactional 或 MessageMarketing
+	Classify(content string) string
+}
+
+// MessageClassifierFunc 函数形式的短信分类器
+type MessageClassifierFunc func(content string) string
+
+// Classify 实现 MessageClassifier 接口
+func (f MessageClassifierFunc) Classify(content string) string {
+	return f(content)
+}
+
+// KeywordClassifier 关键词分类器：包含事务类关键词时为事务类，否则包含营销类关键词时为营销类，都不包含时为事务类
+type KeywordClassifier struct {
+	Marketing     []string // 营销类关键词（为空时使用默认词表）
+	Transactional []string // 事务类关键词（为空时使用默认词表）
+}
+
+// Classify 实现 MessageClassifier 接口
+func (k *KeywordClassifier) Classify(content string) string {
+	transactional := k.Transactional
+	if len(transactional) == 0 {
+		transactional = defaultTransactionalKeywords
+	}
+	marketing := k.Marketing
+	if len(marketing) == 0 {
+		marketing = defaultMarketingKeywords
+	}
+
+	if containsAny(content, transactional) {
+		return MessageTransactional
+	}
+	if containsAny(content, marketing) {
+		return MessageMarketing
+	}
+	return MessageTransactional
+}
+
+// MarketingPolicy 营销短信规则：营销类短信缺少退订提示时自动追加
+type MarketingPolicy struct {
+	Classifier     MessageClassifier // 分类器（默认 KeywordClassifier），始终返回 MessageMarketing 即可将所有短信视为营销类
+	OptOutFooter   string            // 追加的退订提示（默认 DefaultOptOutFooter）
+	OptOutKeywords []string          // 判断内容已包含退订提示的关键词（默认为 退订、拒收）
+	MaxSegments    int               // 营销短信追加退订提示后允许的最多计费条数（0 表示不限制）
+}
+
+// MarketingContent 营销规则处理结果
+type MarketingContent struct {
+	Content     string       // 处理后的短信内容
+	Type        string       // 短信类型：MessageTransactional 或 MessageMarketing
+	FooterAdded bool         // 是否追加了退订提示
+	Segments    *SegmentInfo // 处理后内容的编码与计费条数
+}
+
+// ClassifyContent 判断短信类型（未配置 Config.Marketing 时使用默认的关键词分类器）
+func (c *Client) ClassifyContent(content string) string {
+	return c.marketingPolicy().classifier().Classify(content)
+}
+
+// PrepareContent 按营销规则处理短信内容（需包含签名）：营销类短信缺少退订提示时在正文末尾（结尾签名之前）追加，
+// 追加后超出内容长度上限或 MaxSegments 时返回 *ValidationError
+// 配置 Config.Marketing 后，SMSSend、SMSMultiSend、SMSBatchSend 发送前会自动调用
+func (c *Client) PrepareContent(content string) (*MarketingContent, error) {
+	policy := c.marketingPolicy()
+	result := &MarketingContent{Content: content, Type: policy.classifier().Classify(content)}
+
+	if result.Type == MessageMarketing && !containsAny(content, policy.optOutKeywords()) {
+		result.Content = appendOptOut(content, policy.footer())
+		result.FooterAdded = true
+	}
+	result.Segments = AnalyzeSegments(result.Content, "")
+
+	if result.Type != MessageMarketing {
+		return result, nil
+	}
+	if n := utf8.RuneCountInString(result.Content); n > maxContentLength {
+		return result, c.validationError("content", msgMarketingTooLong, n, maxContentLength)
+	}
+	if policy.MaxSegments > 0 && result.Segments.Segments > policy.MaxSegments {
+		return result, c.validationError("content", msgMarketingSegments, result.Segments.Segments, policy.MaxSegments)
+	}
+	return result, nil
+}
+
+// BalanceFor 获取短信类型对应的余额（营销类为 Balance，事务类为 TransactionalBalance）
+func (resp *SMSBalanceResponse) BalanceFor(messageType string) string {
+	if messageType == MessageMarketing {
+		return resp.Balance
+	}
+	return resp.TransactionalBalance
+}
+
+// marketingContent 发送前按营销规则处理内容，未配置 Config.Marketing 时原样返回
+func (c *Client) marketingContent(content string) (string, error) {
+	if c.marketing == nil {
+		return content, nil
+	}
+	result, err := c.PrepareContent(content)
+	if err != nil {
+		return "", err
+	}
+	return result.Content, nil
+}
+
+// marketingPolicy 客户端的营销规则（未配置时使用默认规则）
+func (c *Client) marketingPolicy() *MarketingPolicy {
+	if c.marketing != nil {
+		return c.marketing
+	}
+	return &MarketingPolicy{}
+}
+
+// classifier 分类器
+func (p *MarketingPolicy) classifier() MessageClassifier {
+	if p.Classifier != nil {
+		return p.Classifier
+	}
+	return &KeywordClassifier{}
+}
+
+// footer 退订提示
+func (p *MarketingPolicy) footer() string {
+	if p.OptOutFooter != "" {
+		return p.OptOutFooter
+	}
+	return DefaultOptOutFooter
+}
+
+// optOutKeywords 退订提示关键词
+func (p *MarketingPolicy) optOutKeywords() []string {
+	if len(p.OptOutKeywords) > 0 {
+		return p.OptOutKeywords
+	}
+	return defaultOptOutKeywords
+}
+
+// appendOptOut 在正文末尾追加退订提示，签名位于结尾时插入到签名之前；正文不以标点结尾时以逗号分隔
+func appendOptOut(content, footer string) string {
+	body, suffix := strings.TrimRightFunc(content, unicode.IsSpace), ""
+	if strings.HasSuffix(body, "】") {
+		if start := strings.LastIndex(body, "【"); start > 0 {
+			body, suffix = body[:start], body[start:]
+		}
+	}
+
+	if last, _ := utf8.DecodeLastRuneInString(body); body != "" && !unicode.IsPunct(last) {
+		body += "，"
+	}
+	return body + footer + suffix
+}
+
+// containsAny 文本是否包含任一关键词（忽略大小写）
+func containsAny(text string, keywords []string) bool {
+	lower := strings.ToLower(text)
+	for _, keyword := range keywords {
+		if keyword != "" && strings.Contains(lower, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package submail_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestKeywordClassifier(t *testing.T) {
+	tests := []struct {
+		classifier *submail.KeywordClassifier
+		content    string
+		want       string
+	}{
+		{&submail.KeywordClassifier{}, "【测试】会员日全场8折优惠", submail.MessageMarketing},
+		{&submail.KeywordClassifier{}, "【测试】您的验证码是1234，限时5分钟", submail.MessageTransactional},
+		{&submail.KeywordClassifier{}, "【测试】您的订单已发货", submail.MessageTransactional},
+		{&submail.KeywordClassifier{Marketing: []string{"SALE"}}, "【测试】Summer sale now on", submail.MessageMarketing},
+		{&submail.KeywordClassifier{Marketing: []string{"SALE"}}, "【测试】全场优惠", submail.MessageTransactional},
+	}
+	for _, tt := range tests {
+		if got := tt.classifier.Classify(tt.content); got != tt.want {
+			t.Errorf("Classify(%q) = %s, want %s", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestPrepareContentAddsFooter(t *testing.T) {
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key"})
+
+	tests := []struct {
+		content string
+		want    string
+		added   bool
+	}{
+		{"【测试】会员日全场优惠", "【测试】会员日全场优惠，回T退订", true},
+		{"会员日全场优惠【测试】", "会员日全场优惠，回T退订【测试】", true},
+		{"会员日全场优惠！【测试】 ", "会员日全场优惠！回T退订【测试】", true},
+		{"【测试】会员日全场优惠，拒收请回复R", "【测试】会员日全场优惠，拒收请回复R", false},
+		{"【测试】您的订单已发货", "【测试】您的订单已发货", false},
+	}
+	for _, tt := range tests {
+		result, err := client.PrepareContent(tt.content)
+		if err != nil {
+			t.Fatalf("PrepareContent(%q): %v", tt.content, err)
+		}
+		if result.Content != tt.want || result.FooterAdded != tt.added || result.Segments == nil {
+			t.Errorf("PrepareContent(%q) = %+v, want %q", tt.content, result, tt.want)
+		}
+	}
+}
+
+func TestPrepareContentMaxSegments(t *testing.T) {
+	client := submail.NewClient(submail.Config{
+		AppID:     "test-app",
+		AppKey:    "test-key",
+		Marketing: &submail.MarketingPolicy{MaxSegments: 1, OptOutFooter: "退订回N"},
+	})
+
+	// 66 个字符加上退订提示后超过单条70字
+	content := "【测试】会员日优惠" + strings.Repeat("好", 57)
+	result, err := client.PrepareContent(content)
+	if !submail.IsValidationError(err) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	if result.Segments.Segments != 2 || !strings.HasSuffix(result.Content, "，退订回N") {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestMarketingPolicyAppliedOnSend(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	config := server.Config()
+	config.Marketing = &submail.MarketingPolicy{
+		Classifier: submail.MessageClassifierFunc(func(content string) string {
+			if strings.Contains(content, "通知") {
+				return submail.MessageTransactional
+			}
+			return submail.MessageMarketing
+		}),
+	}
+	client := submail.NewClient(config)
+
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】新品到店"}); err != nil {
+		t.Fatalf("SMSSend: %v", err)
+	}
+	if msg, _ := server.LastMessage(); msg.Content != "【测试】新品到店，回T退订" {
+		t.Fatalf("sent content = %q", msg.Content)
+	}
+
+	if _, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】停机维护通知",
+		Multi:   []submail.SMSMultiItem{{To: "13800138001"}},
+	}); err != nil {
+		t.Fatalf("SMSMultiSend: %v", err)
+	}
+	if msg, _ := server.LastMessage(); msg.Content != "【测试】停机维护通知" {
+		t.Fatalf("transactional content = %q", msg.Content)
+	}
+}
+
+func TestBalanceFor(t *testing.T) {
+	resp := &submail.SMSBalanceResponse{Balance: "100", TransactionalBalance: "200"}
+	if resp.BalanceFor(submail.MessageMarketing) != "100" || resp.BalanceFor(submail.MessageTransactional) != "200" {
+		t.Fatalf("BalanceFor = %s / %s", resp.BalanceFor(submail.MessageMarketing), resp.BalanceFor(submail.MessageTransactional))
+	}
+}
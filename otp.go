@@ -0,0 +1,341 @@
+package submail
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+// 验证码字符集
+const (
+	OTPDigits       = "0123456789"
+	OTPAlphanumeric = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ" // 去除了易混淆的 0、1、I、O
+)
+
+// 验证码错误，可使用 errors.Is 判断
+var (
+	ErrOTPCooldown         error = sentinelError(msgErrOTPCooldown)
+	ErrOTPExpired          error = sentinelError(msgErrOTPExpired)
+	ErrOTPMismatch         error = sentinelError(msgErrOTPMismatch)
+	ErrOTPAttemptsExceeded error = sentinelError(msgErrOTPAttempts)
+)
+
+// OTPError 验证码发送或校验失败
+type OTPError struct {
+	Err        error         // ErrOTPCooldown、ErrOTPExpired、ErrOTPMismatch 或 ErrOTPAttemptsExceeded
+	Phone      string        // 手机号码
+	RetryAfter time.Duration // 发送过于频繁时需要等待的时间
+	Remaining  int           // 验证码错误时剩余的校验次数
+
+	locale string // 错误信息语言
+}
+
+func (e *OTPError) Error() string {
+	switch e.Err {
+	case ErrOTPCooldown:
+		return localizef(e.locale, msgOTPCooldown, e.RetryAfter.Round(time.Second))
+	case ErrOTPMismatch:
+		return localizef(e.locale, msgOTPMismatch, e.Remaining)
+	case ErrOTPAttemptsExceeded:
+		return localize(e.locale, msgOTPAttemptsExceeded)
+	}
+	return localize(e.locale, msgOTPExpired)
+}
+
+// Is 支持 errors.Is(err, ErrOTPMismatch) 等判断
+func (e *OTPError) Is(target error) bool {
+	return target == e.Err
+}
+
+// OTPRecord 已发送的验证码
+type OTPRecord struct {
+	Hash      string    // 验证码的 HMAC-SHA256 哈希（不保存明文）
+	SentAt    time.Time // 发送时间
+	ExpiresAt time.Time // 过期时间
+	ResendAt  time.Time // 最早可重新发送的时间
+	Attempts  int       // 已校验次数
+}
+
+// OTPStore 验证码存储
+// 多实例部署时可基于 Redis 等共享存储实现，以便多个进程共享验证码与冷却状态
+type OTPStore interface {
+	// Issue 保存新验证码并替换旧验证码；旧记录的 ResendAt 晚于 record.SentAt 时不保存，返回剩余的冷却时间
+	// 必须是原子的，以免并发请求绕过冷却时间
+	Issue(ctx context.Context, key string, record *OTPRecord) (time.Duration, error)
+	// Attempt 原子地将校验次数加一并返回更新后的记录，不存在或已过期（now 晚于 ExpiresAt）时返回 nil
+	Attempt(ctx context.Context, key string, now time.Time) (*OTPRecord, error)
+	// Delete 删除验证码（校验成功或发送失败后调用）
+	Delete(ctx context.Context, key string) error
+}
+
+// OTPConfig 验证码配置
+type OTPConfig struct {
+	Project   string // 短信模板ID（必填），模板变量 @var(code) 为验证码，@var(time) 为有效分钟数
+	Signature string // 自定义短信签名（可选）
+
+	Length      int           // 验证码长度（默认6）
+	Charset     string        // 字符集（默认 OTPDigits，可使用 OTPAlphanumeric）
+	TTL         time.Duration // 有效期（默认5分钟）
+	Cooldown    time.Duration // 同一手机号的重新发送间隔（默认60秒）
+	MaxAttempts int           // 每个验证码的最多校验次数（默认5），超出后需重新获取
+
+	Store OTPStore // 存储（默认进程内存储）
+
+	// Secret 计算验证码哈希的密钥（默认进程启动时随机生成）
+	// 多实例共享存储时必须设置相同的密钥，否则其他实例发送的验证码无法校验
+	Secret []byte
+
+	KeyPrefix string // 存储键前缀（默认 "otp:"），同一存储用于多个场景（如登录、重置密码）时应设置不同的前缀
+}
+
+// OTPVerifier 短信验证码：生成、发送并校验验证码
+//
+//	otp := submail.NewOTPVerifier(client, submail.OTPConfig{Project: "abc123"})
+//	_, err := otp.Send("13800138000")
+//	err = otp.Verify("13800138000", "123456")
+type OTPVerifier struct {
+	sender SMSSender
+	config OTPConfig
+	locale string
+	now    func() time.Time
+}
+
+// NewOTPVerifier 创建验证码校验器，sender 通常为 *Client
+func NewOTPVerifier(sender SMSSender, config OTPConfig) *OTPVerifier {
+	if config.Length <= 0 {
+		config.Length = 6
+	}
+	if config.Charset == "" {
+		config.Charset = OTPDigits
+	}
+	if config.TTL <= 0 {
+		config.TTL = 5 * time.Minute
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = time.Minute
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryOTPStore()
+	}
+	if len(config.Secret) == 0 {
+		config.Secret = make([]byte, 32)
+		rand.Read(config.Secret)
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "otp:"
+	}
+
+	locale := DefaultLocale()
+	if client, ok := sender.(*Client); ok {
+		locale = client.Locale()
+	}
+	return &OTPVerifier{sender: sender, config: config, locale: locale, now: time.Now}
+}
+
+// Send 生成验证码并通过 SMSXSend 发送
+func (v *OTPVerifier) Send(phoneNumber string) (*SMSSendResponse, error) {
+	return v.SendCtx(context.Background(), phoneNumber)
+}
+
+// SendCtx 生成验证码并通过 SMSXSend 发送（支持 context 取消与超时控制）
+// 距上次发送不足 Cooldown 时返回 ErrOTPCooldown（*OTPError，RetryAfter 为剩余等待时间），新验证码发送后旧验证码失效
+func (v *OTPVerifier) SendCtx(ctx context.Context, phoneNumber string) (*SMSSendResponse, error) {
+	if v.config.Project == "" {
+		return nil, &ValidationError{Field: "project", Message: localize(v.locale, msgProjectRequired)}
+	}
+
+	key := v.key(phoneNumber)
+	code, err := v.generate()
+	if err != nil {
+		return nil, err
+	}
+
+	now := v.now()
+	retryAfter, err := v.config.Store.Issue(ctx, key, &OTPRecord{
+		Hash:      v.hash(key, code),
+		SentAt:    now,
+		ExpiresAt: now.Add(v.config.TTL),
+		ResendAt:  now.Add(v.config.Cooldown),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if retryAfter > 0 {
+		return nil, &OTPError{Err: ErrOTPCooldown, Phone: phoneNumber, RetryAfter: retryAfter, locale: v.locale}
+	}
+
+	minutes := int((v.config.TTL + time.Minute - 1) / time.Minute)
+	resp, err := v.sender.SMSXSendCtx(ctx, &SMSXSendRequest{
+		To:           phoneNumber,
+		Project:      v.config.Project,
+		Vars:         map[string]string{"code": code, "time": strconv.Itoa(minutes)},
+		SMSSignature: v.config.Signature,
+	})
+	switch {
+	case err != nil:
+	case resp == nil:
+		err = localizedError(v.locale, msgNoResponse, EndpointSMSXSend)
+	case resp.Status != "success":
+		err = NewAPIErrorWithLocale(v.locale, resp.Code, resp.Msg)
+	}
+	if err != nil {
+		// 发送失败时删除验证码，允许立即重新发送
+		v.config.Store.Delete(context.WithoutCancel(ctx), key)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Verify 校验验证码，成功后验证码失效
+func (v *OTPVerifier) Verify(phoneNumber, code string) error {
+	return v.VerifyCtx(context.Background(), phoneNumber, code)
+}
+
+// VerifyCtx 校验验证码（支持 context 取消与超时控制）
+// 失败时返回 *OTPError：ErrOTPExpired（未发送或已过期）、ErrOTPMismatch（Remaining 为剩余次数）
+// 或 ErrOTPAttemptsExceeded（校验次数超过 MaxAttempts，需重新获取）
+func (v *OTPVerifier) VerifyCtx(ctx context.Context, phoneNumber, code string) error {
+	key := v.key(phoneNumber)
+
+	// 先计数再比较，并发校验也不会超过最多次数
+	record, err := v.config.Store.Attempt(ctx, key, v.now())
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return &OTPError{Err: ErrOTPExpired, Phone: phoneNumber, locale: v.locale}
+	}
+	if record.Attempts > v.config.MaxAttempts {
+		return &OTPError{Err: ErrOTPAttemptsExceeded, Phone: phoneNumber, locale: v.locale}
+	}
+
+	expected := []byte(record.Hash)
+	actual := []byte(v.hash(key, v.normalize(code)))
+	if subtle.ConstantTimeCompare(expected, actual) != 1 {
+		remaining := v.config.MaxAttempts - record.Attempts
+		if remaining <= 0 {
+			return &OTPError{Err: ErrOTPAttemptsExceeded, Phone: phoneNumber, locale: v.locale}
+		}
+		return &OTPError{Err: ErrOTPMismatch, Phone: phoneNumber, Remaining: remaining, locale: v.locale}
+	}
+
+	return v.config.Store.Delete(ctx, key)
+}
+
+// key 存储键，手机号统一为 E.164 格式，避免同一号码的不同写法绕过冷却时间
+func (v *OTPVerifier) key(phoneNumber string) string {
+	if normalized, err := phone.Normalize(phoneNumber); err == nil {
+		phoneNumber = normalized
+	}
+	return v.config.KeyPrefix + strings.TrimSpace(phoneNumber)
+}
+
+// generate 使用 crypto/rand 生成验证码
+func (v *OTPVerifier) generate() (string, error) {
+	charset := []rune(v.config.Charset)
+	max := big.NewInt(int64(len(charset)))
+
+	code := make([]rune, v.config.Length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = charset[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// normalize 规范化用户输入的验证码：去除空白，字符集不含小写字母时不区分大小写
+func (v *OTPVerifier) normalize(code string) string {
+	code = strings.TrimSpace(code)
+	if strings.ToUpper(v.config.Charset) == v.config.Charset {
+		code = strings.ToUpper(code)
+	}
+	return code
+}
+
+// hash 计算验证码哈希（包含存储键，验证码不能用于其他号码）
+func (v *OTPVerifier) hash(key, code string) string {
+	mac := hmac.New(sha256.New, v.config.Secret)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ===== 进程内验证码存储 =====
+
+// MemoryOTPStore 进程内验证码存储（并发安全）
+type MemoryOTPStore struct {
+	mu      sync.Mutex
+	records map[string]*OTPRecord
+	ops     int
+}
+
+// NewMemoryOTPStore 创建进程内验证码存储
+func NewMemoryOTPStore() *MemoryOTPStore {
+	return &MemoryOTPStore{records: make(map[string]*OTPRecord)}
+}
+
+// Issue 实现 OTPStore 接口
+func (s *MemoryOTPStore) Issue(ctx context.Context, key string, record *OTPRecord) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(record.SentAt)
+	if old, ok := s.records[key]; ok && old.ResendAt.After(record.SentAt) {
+		return old.ResendAt.Sub(record.SentAt), nil
+	}
+	r := *record
+	s.records[key] = &r
+	return 0, nil
+}
+
+// Attempt 实现 OTPStore 接口
+func (s *MemoryOTPStore) Attempt(ctx context.Context, key string, now time.Time) (*OTPRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || now.After(record.ExpiresAt) {
+		return nil, nil
+	}
+	record.Attempts++
+	r := *record
+	return &r, nil
+}
+
+// Delete 实现 OTPStore 接口
+func (s *MemoryOTPStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// sweep 定期清理已过期且已过冷却时间的记录，避免内存无限增长
+func (s *MemoryOTPStore) sweep(now time.Time) {
+	s.ops++
+	if s.ops%1024 != 0 {
+		return
+	}
+	for key, record := range s.records {
+		if now.After(record.ExpiresAt) && now.After(record.ResendAt) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package submail_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+// recordingOTPStore 记录保存的验证码记录
+type recordingOTPStore struct {
+	*submail.MemoryOTPStore
+	issued []submail.OTPRecord
+}
+
+func (s *recordingOTPStore) Issue(ctx context.Context, key string, record *submail.OTPRecord) (time.Duration, error) {
+	s.issued = append(s.issued, *record)
+	return s.MemoryOTPStore.Issue(ctx, key, record)
+}
+
+// sentCode 取出最近一次发送的验证码
+func sentCode(t *testing.T, recorder *submailtest.Recorder) string {
+	t.Helper()
+	calls := recorder.CallsOf("SMSXSend")
+	if len(calls) == 0 {
+		t.Fatal("no verification code sent")
+	}
+	return calls[len(calls)-1].Request.(*submail.SMSXSendRequest).Vars["code"]
+}
+
+func TestOTPSendAndVerify(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	store := &recordingOTPStore{MemoryOTPStore: submail.NewMemoryOTPStore()}
+	otp := submail.NewOTPVerifier(recorder, submail.OTPConfig{Project: "abc123", MaxAttempts: 3, Store: store})
+
+	if _, err := otp.Send("13800138000"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	req := recorder.CallsOf("SMSXSend")[0].Request.(*submail.SMSXSendRequest)
+	code := req.Vars["code"]
+	if len(code) != 6 || strings.Trim(code, submail.OTPDigits) != "" || req.Vars["time"] != "5" {
+		t.Fatalf("vars = %v", req.Vars)
+	}
+	// 存储中只保存哈希
+	if hash := store.issued[0].Hash; len(hash) != 64 || strings.Contains(hash, code) {
+		t.Fatalf("stored hash = %q", hash)
+	}
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	var otpErr *submail.OTPError
+	if err := otp.Verify("13800138000", wrong); !errors.As(err, &otpErr) || !errors.Is(err, submail.ErrOTPMismatch) || otpErr.Remaining != 2 {
+		t.Fatalf("wrong code err = %v", err)
+	}
+	// 同一号码的不同写法使用同一验证码
+	if err := otp.Verify("+86 138 0013 8000", " "+code+" "); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := otp.Verify("13800138000", code); !errors.Is(err, submail.ErrOTPExpired) {
+		t.Fatalf("reused code err = %v, want ErrOTPExpired", err)
+	}
+}
+
+func TestOTPCooldown(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	otp := submail.NewOTPVerifier(recorder, submail.OTPConfig{Project: "abc123"})
+
+	otp.Send("13800138000")
+	_, err := otp.Send("+8613800138000")
+	var otpErr *submail.OTPError
+	if !errors.As(err, &otpErr) || !errors.Is(err, submail.ErrOTPCooldown) || otpErr.RetryAfter <= 0 {
+		t.Fatalf("resend err = %v, want ErrOTPCooldown", err)
+	}
+	if n := len(recorder.CallsOf("SMSXSend")); n != 1 {
+		t.Fatalf("sent %d codes, want 1", n)
+	}
+}
+
+func TestOTPSendFailureAllowsResend(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	recorder.ReturnNext("SMSXSend", nil, submail.NewAPIError(submail.ErrTemplateInvalid, "invalid"))
+	otp := submail.NewOTPVerifier(recorder, submail.OTPConfig{Project: "abc123"})
+
+	if _, err := otp.Send("13800138000"); !submail.IsAPIErrorCode(err, submail.ErrTemplateInvalid) {
+		t.Fatalf("Send err = %v, want API error", err)
+	}
+	if _, err := otp.Send("13800138000"); err != nil {
+		t.Fatalf("resend after failure: %v", err)
+	}
+}
+
+func TestOTPAttemptsExceeded(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	otp := submail.NewOTPVerifier(recorder, submail.OTPConfig{
+		Project:     "abc123",
+		Charset:     submail.OTPAlphanumeric,
+		Length:      8,
+		MaxAttempts: 2,
+	})
+
+	otp.Send("13800138000")
+	code := sentCode(t, recorder)
+
+	otp.Verify("13800138000", "wrong")
+	if err := otp.Verify("13800138000", "wrong"); !errors.Is(err, submail.ErrOTPAttemptsExceeded) {
+		t.Fatalf("last attempt err = %v, want ErrOTPAttemptsExceeded", err)
+	}
+	// 超过次数后正确的验证码同样失效
+	if err := otp.Verify("13800138000", strings.ToLower(code)); !errors.Is(err, submail.ErrOTPAttemptsExceeded) {
+		t.Fatalf("correct code after limit err = %v", err)
+	}
+}
+
+func TestOTPAlphanumericIgnoresCase(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	otp := submail.NewOTPVerifier(recorder, submail.OTPConfig{Project: "abc123", Charset: submail.OTPAlphanumeric})
+
+	otp.Send("13800138000")
+	if err := otp.Verify("13800138000", strings.ToLower(sentCode(t, recorder))); err != nil {
+		t.Fatalf("Verify lowercase: %v", err)
+	}
+	if _, err := submail.NewOTPVerifier(recorder, submail.OTPConfig{}).Send("13800138000"); !submail.IsValidationError(err) {
+		t.Fatalf("Send without project err = %v, want *ValidationError", err)
+	}
+}
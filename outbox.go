@@ -0,0 +1,516 @@
+package submail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// 发件箱消息状态
+const (
+	OutboxPending  = "pending"  // 待发送（含等待重试）
+	OutboxSending  = "sending"  // 已被领取，正在发送
+	OutboxSent     = "sent"     // 已发送（SendID 为API返回的发送ID）
+	OutboxDead     = "dead"     // 死信：不可重试的错误或超过重试次数，需人工处理后通过 Requeue 重新发送
+	OutboxCanceled = "canceled" // 已取消（发送前通过 Cancel 取消）
+	OutboxSkipped  = "skipped"  // 已跳过：收件人均在屏蔽名单中，请求未发出
+)
+
+// ErrOutboxNotFound 发件箱中不存在该消息，可使用 errors.Is 判断
+var ErrOutboxNotFound error = sentinelError(msgErrOutboxNotFound)
+
+// OutboxMessage 发件箱消息
+type OutboxMessage struct {
+	ID        string            `json:"id"`                  // 消息ID（入队时为空则自动生成）
+	Endpoint  string            `json:"endpoint"`            // 发送接口：EndpointSMSSend、EndpointSMSXSend 或 EndpointSMSBatchXSend
+	To        string            `json:"to"`                  // 收件人手机号码（批量群发时多个号码用逗号分隔）
+	Content   string            `json:"content,omitempty"`   // 短信正文（SMSSend）
+	Project   string            `json:"project,omitempty"`   // 模板ID（SMSXSend、SMSBatchXSend）
+	Vars      map[string]string `json:"vars,omitempty"`      // 模板变量（SMSXSend、SMSBatchXSend）
+	Signature string            `json:"signature,omitempty"` // 自定义短信签名（SMSXSend、SMSBatchXSend）
+	Tag       string            `json:"tag,omitempty"`       // 自定义标签
+
+	Status        string    `json:"status"`                // 状态
+	Attempts      int       `json:"attempts"`              // 已发送次数
+	NextAttemptAt time.Time `json:"next_attempt_at"`       // 最早发送时间（重试时为退避后的时间）
+	LeaseUntil    time.Time `json:"lease_until,omitempty"` // 发送中状态的租约到期时间
+	SendID        string    `json:"send_id,omitempty"`     // 发送成功后API返回的发送ID（批量群发时为批量任务ID）
+	Fee           int       `json:"fee,omitempty"`         // 计费条数
+	ErrorCode     int       `json:"error_code,omitempty"`  // 最近一次失败的API错误码
+	LastError     string    `json:"last_error,omitempty"`  // 最近一次失败的原因
+	CreatedAt     time.Time `json:"created_at"`            // 入队时间
+	UpdatedAt     time.Time `json:"updated_at"`            // 最后更新时间
+}
+
+// clone 复制消息（存储实现返回副本，避免调用方修改内部状态）
+func (m *OutboxMessage) clone() *OutboxMessage {
+	c := *m
+	if m.Vars != nil {
+		c.Vars = make(map[string]string, len(m.Vars))
+		for k, v := range m.Vars {
+			c.Vars[k] = v
+		}
+	}
+	return &c
+}
+
+// OutboxStore 发件箱存储
+// 内置 MemoryOutboxStore、FileOutboxStore（嵌入式文件存储）与 SQLOutboxStore（database/sql），
+// 也可基于 Redis 等自行实现
+type OutboxStore interface {
+	// Add 保存新消息
+	Add(ctx context.Context, msg *OutboxMessage) error
+	// Get 获取消息，不存在时返回 ErrOutboxNotFound
+	Get(ctx context.Context, id string) (*OutboxMessage, error)
+	// Update 保存消息的最新状态，不存在时返回 ErrOutboxNotFound
+	Update(ctx context.Context, msg *OutboxMessage) error
+	// Transition 仅当存储中消息的状态为 from 时原子地保存 msg（msg.Status 为目标状态），返回是否保存
+	// 不存在时返回 ErrOutboxNotFound；用于取消、重新入队等可能与 Claim 并发的状态变更
+	Transition(ctx context.Context, msg *OutboxMessage, from string) (bool, error)
+	// Claim 领取最多 limit 条已到发送时间的待发送消息，将其原子地标记为发送中并设置租约到期时间 leaseUntil
+	// 同一条消息只能被一个调用方领取
+	Claim(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]*OutboxMessage, error)
+	// Expired 获取租约已过期的发送中消息（进程在发送过程中退出时遗留）
+	Expired(ctx context.Context, now time.Time, limit int) ([]*OutboxMessage, error)
+	// List 按状态列出消息（status 为空时列出全部），limit 小于等于0时不限制数量
+	List(ctx context.Context, status string, limit int) ([]*OutboxMessage, error)
+}
+
+// OutboxConfig 发件箱配置
+type OutboxConfig struct {
+	Store OutboxStore // 存储（必填）
+
+	// RetryPolicy 发送失败后的重试策略（默认最多发送5次，指数退避1秒至5分钟）
+	// 与客户端的重试策略一样，可能已被服务器受理的失败默认不重试，以避免重复发送
+	RetryPolicy RetryPolicy
+
+	BatchSize    int           // 每次领取的消息数（默认100）
+	Workers      int           // 并发发送数（默认4）
+	PollInterval time.Duration // 没有待发送消息时的轮询间隔（默认1秒）
+	Lease        time.Duration // 发送中状态的租约时长（默认5分钟），应大于单次发送的最长耗时
+
+	// ResendExpired 租约过期的发送中消息（发送过程中进程退出）是否重新发送
+	// 默认转为死信由人工确认，因为无法确定上次请求是否已被受理；设置为 true 时重新发送（可能重复发送）
+	ResendExpired bool
+
+	OnSent func(msg *OutboxMessage) // 消息发送成功后回调
+	OnDead func(msg *OutboxMessage) // 消息转为死信后回调
+}
+
+// Outbox 持久化发件箱：消息先写入存储，再由 Run 在后台通过客户端发送，失败时按重试策略重试
+//
+//	store, _ := submail.NewFileOutboxStore("data/outbox.log")
+//	outbox := submail.NewOutbox(client, submail.OutboxConfig{Store: store})
+//	go outbox.Run(ctx)
+//	id, err := outbox.EnqueueXSend(ctx, &submail.SMSXSendRequest{To: "13800138000", Project: "abc123"})
+type Outbox struct {
+	sender SMSSender
+	config OutboxConfig
+	locale string
+	now    func() time.Time
+
+	// holdUntil 返回不早于给定时间的最早可发送时间（定时发送的免打扰时段），为空时不限制
+	holdUntil func(t time.Time) time.Time
+}
+
+// NewOutbox 创建发件箱，sender 通常为 *Client
+func NewOutbox(sender SMSSender, config OutboxConfig) *Outbox {
+	if config.RetryPolicy == nil {
+		config.RetryPolicy = &BackoffRetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Minute,
+			Multiplier:     2,
+			Jitter:         0.2,
+		}
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.Lease <= 0 {
+		config.Lease = 5 * time.Minute
+	}
+
+	locale := DefaultLocale()
+	if client, ok := sender.(*Client); ok {
+		locale = client.Locale()
+	}
+	return &Outbox{sender: sender, config: config, locale: locale, now: time.Now}
+}
+
+// Enqueue 将消息写入发件箱，返回消息ID
+func (o *Outbox) Enqueue(ctx context.Context, msg *OutboxMessage) (string, error) {
+	if msg == nil {
+		return "", localizedError(o.locale, msgRequestNil)
+	}
+
+	msg = msg.clone()
+	now := o.now()
+	if msg.ID == "" {
+		msg.ID = newOutboxID()
+	}
+	msg.Status = OutboxPending
+	if msg.NextAttemptAt.IsZero() {
+		msg.NextAttemptAt = now
+	}
+	msg.CreatedAt = now
+	msg.UpdatedAt = now
+
+	if err := o.config.Store.Add(ctx, msg); err != nil {
+		return "", err
+	}
+	return msg.ID, nil
+}
+
+// EnqueueSend 将短信发送请求写入发件箱
+func (o *Outbox) EnqueueSend(ctx context.Context, req *SMSSendRequest) (string, error) {
+	if req == nil {
+		return "", localizedError(o.locale, msgRequestNil)
+	}
+	return o.Enqueue(ctx, &OutboxMessage{Endpoint: EndpointSMSSend, To: req.To, Content: req.Content, Tag: req.Tag})
+}
+
+// EnqueueXSend 将短信模板发送请求写入发件箱
+func (o *Outbox) EnqueueXSend(ctx context.Context, req *SMSXSendRequest) (string, error) {
+	if req == nil {
+		return "", localizedError(o.locale, msgRequestNil)
+	}
+	return o.Enqueue(ctx, &OutboxMessage{
+		Endpoint:  EndpointSMSXSend,
+		To:        req.To,
+		Project:   req.Project,
+		Vars:      req.Vars,
+		Signature: req.SMSSignature,
+		Tag:       req.Tag,
+	})
+}
+
+// EnqueueBatchXSend 将批量模板群发请求写入发件箱
+func (o *Outbox) EnqueueBatchXSend(ctx context.Context, req *SMSBatchXSendRequest) (string, error) {
+	if req == nil {
+		return "", localizedError(o.locale, msgRequestNil)
+	}
+	return o.Enqueue(ctx, &OutboxMessage{
+		Endpoint:  EndpointSMSBatchXSend,
+		To:        req.To,
+		Project:   req.Project,
+		Vars:      req.Vars,
+		Signature: req.SMSSignature,
+		Tag:       req.Tag,
+	})
+}
+
+// Get 获取消息
+func (o *Outbox) Get(ctx context.Context, id string) (*OutboxMessage, error) {
+	return o.config.Store.Get(ctx, id)
+}
+
+// Requeue 将死信重新加入待发送队列（重置发送次数）
+func (o *Outbox) Requeue(ctx context.Context, id string) error {
+	msg, err := o.config.Store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if msg.Status != OutboxDead {
+		return localizedError(o.locale, msgOutboxStatus, id, msg.Status)
+	}
+
+	now := o.now()
+	msg.Status = OutboxPending
+	msg.Attempts = 0
+	msg.NextAttemptAt = now
+	msg.UpdatedAt = now
+	return o.transition(ctx, msg, OutboxDead, msgOutboxStatus)
+}
+
+// Cancel 取消尚未发送的消息，只能取消待发送（含等待重试）的消息
+func (o *Outbox) Cancel(ctx context.Context, id string) error {
+	msg, err := o.config.Store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if msg.Status != OutboxPending {
+		return localizedError(o.locale, msgOutboxNotPending, id, msg.Status)
+	}
+
+	msg.Status = OutboxCanceled
+	msg.UpdatedAt = o.now()
+	return o.transition(ctx, msg, OutboxPending, msgOutboxNotPending)
+}
+
+// transition 以 from 为条件保存状态变更，状态已被并发修改（如已被领取发送）时返回 key 对应的错误
+func (o *Outbox) transition(ctx context.Context, msg *OutboxMessage, from, key string) error {
+	ok, err := o.config.Store.Transition(ctx, msg, from)
+	if err != nil || ok {
+		return err
+	}
+
+	current, err := o.config.Store.Get(ctx, msg.ID)
+	if err != nil {
+		return err
+	}
+	return localizedError(o.locale, key, msg.ID, current.Status)
+}
+
+// Run 持续发送到期的消息，直到 ctx 取消
+func (o *Outbox) Run(ctx context.Context) error {
+	for {
+		n, err := o.DispatchOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil && n >= o.config.BatchSize {
+			continue
+		}
+
+		timer := time.NewTimer(o.config.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// DispatchOnce 处理租约过期的消息，并领取、发送一批到期的消息，返回领取的消息数
+func (o *Outbox) DispatchOnce(ctx context.Context) (int, error) {
+	if err := o.recoverExpired(ctx); err != nil {
+		return 0, err
+	}
+
+	now := o.now()
+	messages, err := o.config.Store.Claim(ctx, now, o.config.BatchSize, now.Add(o.config.Lease))
+	if err != nil {
+		return 0, err
+	}
+
+	sem := make(chan struct{}, o.config.Workers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for i, msg := range messages {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			// 停止时尚未开始发送的消息放回待发送队列
+			if err := o.release(ctx, messages[i:]...); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(msg *OutboxMessage) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := o.dispatch(ctx, msg); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(msg)
+	}
+	wg.Wait()
+	return len(messages), firstErr
+}
+
+// release 将已领取但未发出的消息放回待发送队列（不计入发送次数）
+// 使用不随 ctx 取消的 context 保存，保证停止时状态能够写回
+func (o *Outbox) release(ctx context.Context, messages ...*OutboxMessage) error {
+	now := o.now()
+	for _, msg := range messages {
+		msg.Status = OutboxPending
+		msg.LeaseUntil = time.Time{}
+		msg.UpdatedAt = now
+		if _, err := o.config.Store.Transition(context.WithoutCancel(ctx), msg, OutboxSending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverExpired 处理租约过期的发送中消息
+func (o *Outbox) recoverExpired(ctx context.Context) error {
+	now := o.now()
+	expired, err := o.config.Store.Expired(ctx, now, o.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range expired {
+		msg.LeaseUntil = time.Time{}
+		msg.UpdatedAt = now
+		if o.config.ResendExpired {
+			msg.Status = OutboxPending
+			msg.NextAttemptAt = now
+		} else {
+			msg.Status = OutboxDead
+			msg.ErrorCode = 0
+			msg.LastError = localize(o.locale, msgOutboxLeaseExpired)
+		}
+		ok, err := o.config.Store.Transition(ctx, msg, OutboxSending)
+		if err != nil {
+			return err
+		}
+		if ok && msg.Status == OutboxDead && o.config.OnDead != nil {
+			o.config.OnDead(msg)
+		}
+	}
+	return nil
+}
+
+// dispatch 发送单条消息并保存结果
+// 结果使用不随 ctx 取消的 context 保存，停止时已发出请求的结果不会丢失
+func (o *Outbox) dispatch(ctx context.Context, msg *OutboxMessage) error {
+	if ctx.Err() != nil {
+		return o.release(ctx, msg)
+	}
+	if o.holdUntil != nil {
+		now := o.now()
+		if next := o.holdUntil(now); next.After(now) {
+			// 处于免打扰时段（如重试时间落入时段内），延后到时段结束后发送
+			msg.Status = OutboxPending
+			msg.NextAttemptAt = next
+			msg.LeaseUntil = time.Time{}
+			msg.UpdatedAt = now
+			_, err := o.config.Store.Transition(ctx, msg, OutboxSending)
+			return err
+		}
+	}
+
+	resp, err := o.send(ctx, msg)
+	switch {
+	case err != nil:
+	case resp == nil:
+		err = localizedError(o.locale, msgNoResponse, msg.Endpoint)
+	case resp.Status == SendStatusSkipped:
+		err = ErrSuppressed
+	case resp.Status != "success":
+		err = NewAPIErrorWithLocale(o.locale, resp.Code, resp.Msg)
+	}
+	now := o.now()
+	msg.LeaseUntil = time.Time{}
+	msg.UpdatedAt = now
+
+	switch {
+	case err == nil:
+		msg.Attempts++
+		msg.Status = OutboxSent
+		msg.SendID = resp.SendID
+		msg.Fee = resp.Fee
+		msg.ErrorCode = 0
+		msg.LastError = ""
+
+	case errors.Is(err, ErrSuppressed):
+		// 收件人均在屏蔽名单中，请求未发出：标记为已跳过，不转为死信
+		msg.Status = OutboxSkipped
+		msg.ErrorCode = 0
+		msg.LastError = err.Error()
+
+	case ctx.Err() != nil && !requestMayBeSent(err):
+		// 发送被取消且请求未发出：放回待发送队列
+		return o.release(ctx, msg)
+
+	case ctx.Err() != nil && isContextError(err):
+		// 发送被取消，请求可能已发出：保持发送中状态，租约过期后按 ResendExpired 处理
+		return nil
+
+	default:
+		msg.LastError = err.Error()
+		msg.ErrorCode = 0
+		if apiErr, ok := AsAPIError(err); ok {
+			msg.ErrorCode = apiErr.Code
+		}
+
+		delay, retry := o.retryDelay(msg, err)
+		if !retry {
+			msg.Status = OutboxDead
+			break
+		}
+		msg.Status = OutboxPending
+		msg.NextAttemptAt = now.Add(delay)
+	}
+
+	ok, err := o.config.Store.Transition(context.WithoutCancel(ctx), msg, OutboxSending)
+	if err != nil || !ok {
+		// 未保存说明租约已过期并被其他进程处理
+		return err
+	}
+	switch {
+	case msg.Status == OutboxSent && o.config.OnSent != nil:
+		o.config.OnSent(msg)
+	case msg.Status == OutboxDead && o.config.OnDead != nil:
+		o.config.OnDead(msg)
+	}
+	return nil
+}
+
+// retryDelay 判断发送失败的消息是否重试及重试前的等待时间
+// 客户端限流或熔断时请求未发出，不计入发送次数；其他错误按重试策略判断
+func (o *Outbox) retryDelay(msg *OutboxMessage, err error) (time.Duration, bool) {
+	var limitErr *RateLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.RetryAfter, true
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return o.config.PollInterval, true
+	}
+
+	msg.Attempts++
+	return o.config.RetryPolicy.ShouldRetry(&RetryRequest{
+		Method:   "POST",
+		Endpoint: msg.Endpoint,
+		Attempt:  msg.Attempts,
+		Sent:     requestMayBeSent(err),
+	}, err)
+}
+
+// send 按消息的发送接口调用客户端
+func (o *Outbox) send(ctx context.Context, msg *OutboxMessage) (*SMSSendResponse, error) {
+	switch msg.Endpoint {
+	case EndpointSMSSend:
+		return o.sender.SMSSendCtx(ctx, &SMSSendRequest{To: msg.To, Content: msg.Content, Tag: msg.Tag})
+	case EndpointSMSXSend:
+		return o.sender.SMSXSendCtx(ctx, &SMSXSendRequest{
+			To:           msg.To,
+			Project:      msg.Project,
+			Vars:         msg.Vars,
+			SMSSignature: msg.Signature,
+			Tag:          msg.Tag,
+		})
+	case EndpointSMSBatchXSend:
+		resp, err := o.sender.SMSBatchXSendCtx(ctx, &SMSBatchXSendRequest{
+			To:           msg.To,
+			Project:      msg.Project,
+			Vars:         msg.Vars,
+			SMSSignature: msg.Signature,
+			Tag:          msg.Tag,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &SMSSendResponse{BaseResponse: resp.BaseResponse, SendID: resp.BatchList, Fee: resp.TotalFee}, nil
+	}
+	return nil, &ValidationError{Field: "endpoint", Message: localizef(o.locale, msgUnsupportedEndpoint, msg.Endpoint)}
+}
+
+// newOutboxID 生成发件箱消息ID
+func newOutboxID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,318 @@
+package submail
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLOutboxStore 支持的数据库（决定占位符、建表语句与分页语法）
+const (
+	SQLDialectSQLite   = "sqlite"   // SQLite：? 占位符
+	SQLDialectMySQL    = "mysql"    // MySQL、MariaDB：? 占位符
+	SQLDialectPostgres = "postgres" // PostgreSQL：$1、$2 占位符
+)
+
+// outboxColumns 发件箱表的列（顺序与 scanOutboxMessage 一致）
+const outboxColumns = "id, endpoint, recipient, content, project, vars, signature, tag, status, attempts, " +
+	"next_attempt_at, lease_until, send_id, fee, error_code, last_error, created_at, updated_at"
+
+// SQLOutboxStore 基于 database/sql 的发件箱存储，可在多个进程间共享
+// SDK 不引入数据库驱动，由调用方导入驱动并传入 *sql.DB；时间以 Unix 毫秒存储。
+// 支持 SQLite、MySQL 与 PostgreSQL，其他数据库可参考实现 OutboxStore 接口
+type SQLOutboxStore struct {
+	db      *sql.DB
+	table   string
+	dialect string
+}
+
+// NewSQLOutboxStore 创建 database/sql 发件箱存储
+// table 为表名（默认 submail_outbox），dialect 为数据库类型（默认 SQLDialectSQLite）
+func NewSQLOutboxStore(db *sql.DB, table, dialect string) *SQLOutboxStore {
+	if table == "" {
+		table = "submail_outbox"
+	}
+	if dialect == "" {
+		dialect = SQLDialectSQLite
+	}
+	return &SQLOutboxStore{db: db, table: table, dialect: dialect}
+}
+
+// CreateTable 创建发件箱表及索引（已存在时跳过），也可以参考此语句自行建表
+func (s *SQLOutboxStore) CreateTable(ctx context.Context) error {
+	// MySQL 不支持 CREATE INDEX IF NOT EXISTS，索引在建表语句中声明
+	index := ""
+	if s.dialect == SQLDialectMySQL {
+		index = ",\n\tINDEX " + s.table + "_status_next (status, next_attempt_at)"
+	}
+
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS ` + s.table + ` (
+	id VARCHAR(64) NOT NULL PRIMARY KEY,
+	endpoint VARCHAR(64) NOT NULL,
+	recipient TEXT NOT NULL,
+	content TEXT NOT NULL,
+	project VARCHAR(64) NOT NULL,
+	vars TEXT NOT NULL,
+	signature VARCHAR(64) NOT NULL,
+	tag VARCHAR(64) NOT NULL,
+	status VARCHAR(16) NOT NULL,
+	attempts INTEGER NOT NULL,
+	next_attempt_at BIGINT NOT NULL,
+	lease_until BIGINT NOT NULL,
+	send_id VARCHAR(64) NOT NULL,
+	fee INTEGER NOT NULL,
+	error_code INTEGER NOT NULL,
+	last_error TEXT NOT NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL` + index + `
+)`,
+	}
+	if s.dialect != SQLDialectMySQL {
+		statements = append(statements,
+			`CREATE INDEX IF NOT EXISTS `+s.table+`_status_next ON `+s.table+` (status, next_attempt_at)`)
+	}
+	for _, stmt := range statements {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add 实现 OutboxStore 接口
+func (s *SQLOutboxStore) Add(ctx context.Context, msg *OutboxMessage) error {
+	args, err := outboxArgs(msg)
+	if err != nil {
+		return err
+	}
+	query := "INSERT INTO " + s.table + " (" + outboxColumns + ") VALUES (" + s.bindList(1, len(args)) + ")"
+	_, err = s.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Get 实现 OutboxStore 接口
+func (s *SQLOutboxStore) Get(ctx context.Context, id string) (*OutboxMessage, error) {
+	query := "SELECT " + outboxColumns + " FROM " + s.table + " WHERE id = " + s.bind(1)
+	msg, err := scanOutboxMessage(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOutboxNotFound
+	}
+	return msg, err
+}
+
+// Update 实现 OutboxStore 接口
+func (s *SQLOutboxStore) Update(ctx context.Context, msg *OutboxMessage) error {
+	n, err := s.update(ctx, msg, "")
+	if err != nil || n > 0 {
+		return err
+	}
+	// MySQL 返回实际修改的行数，新值与原值相同时为0：消息存在即视为更新成功
+	_, err = s.Get(ctx, msg.ID)
+	return err
+}
+
+// Transition 实现 OutboxStore 接口
+// 以 UPDATE ... WHERE id = ? AND status = ? 实现，多个进程并发修改同一消息时只有一个成功
+func (s *SQLOutboxStore) Transition(ctx context.Context, msg *OutboxMessage, from string) (bool, error) {
+	n, err := s.update(ctx, msg, from)
+	if err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return true, nil
+	}
+	// 未更新任何行：状态已变化，或（MySQL）新值与原值相同，重新读取后与目标值比较
+	current, err := s.Get(ctx, msg.ID)
+	if err != nil {
+		return false, err
+	}
+	return sameOutboxRow(current, msg), nil
+}
+
+// update 更新消息的全部列，from 不为空时仅在状态为 from 时更新，返回更新的行数
+func (s *SQLOutboxStore) update(ctx context.Context, msg *OutboxMessage, from string) (int64, error) {
+	args, err := outboxArgs(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := strings.Split(outboxColumns, ", ")[1:]
+	sets := make([]string, len(columns))
+	for i, column := range columns {
+		sets[i] = column + " = " + s.bind(i+1)
+	}
+	query := "UPDATE " + s.table + " SET " + strings.Join(sets, ", ") + " WHERE id = " + s.bind(len(columns)+1)
+	args = append(args[1:], msg.ID)
+	if from != "" {
+		query += " AND status = " + s.bind(len(columns)+2)
+		args = append(args, from)
+	}
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Claim 实现 OutboxStore 接口
+// 先查询候选消息，再逐条以状态为条件更新，只有更新成功的消息视为领取成功，多个进程并发领取时不会重复
+func (s *SQLOutboxStore) Claim(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]*OutboxMessage, error) {
+	query := "SELECT " + outboxColumns + " FROM " + s.table +
+		" WHERE status = " + s.bind(1) + " AND next_attempt_at <= " + s.bind(2) + " ORDER BY next_attempt_at, id"
+	candidates, err := s.query(ctx, query, limit, OutboxPending, now.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+
+	update := "UPDATE " + s.table + " SET status = " + s.bind(1) + ", lease_until = " + s.bind(2) + ", updated_at = " + s.bind(3) +
+		" WHERE id = " + s.bind(4) + " AND status = " + s.bind(5)
+	claimed := make([]*OutboxMessage, 0, len(candidates))
+	for _, msg := range candidates {
+		result, err := s.db.ExecContext(ctx, update, OutboxSending, leaseUntil.UnixMilli(), now.UnixMilli(), msg.ID, OutboxPending)
+		if err != nil {
+			return claimed, err
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		msg.Status = OutboxSending
+		msg.LeaseUntil = time.UnixMilli(leaseUntil.UnixMilli())
+		msg.UpdatedAt = time.UnixMilli(now.UnixMilli())
+		claimed = append(claimed, msg)
+	}
+	return claimed, nil
+}
+
+// Expired 实现 OutboxStore 接口
+func (s *SQLOutboxStore) Expired(ctx context.Context, now time.Time, limit int) ([]*OutboxMessage, error) {
+	query := "SELECT " + outboxColumns + " FROM " + s.table +
+		" WHERE status = " + s.bind(1) + " AND lease_until < " + s.bind(2) + " ORDER BY lease_until, id"
+	return s.query(ctx, query, limit, OutboxSending, now.UnixMilli())
+}
+
+// List 实现 OutboxStore 接口
+func (s *SQLOutboxStore) List(ctx context.Context, status string, limit int) ([]*OutboxMessage, error) {
+	if status == "" {
+		return s.query(ctx, "SELECT "+outboxColumns+" FROM "+s.table+" ORDER BY next_attempt_at, id", limit)
+	}
+	query := "SELECT " + outboxColumns + " FROM " + s.table + " WHERE status = " + s.bind(1) + " ORDER BY next_attempt_at, id"
+	return s.query(ctx, query, limit, status)
+}
+
+// query 执行查询并读取最多 limit 条消息（limit 小于等于0时不限制）
+func (s *SQLOutboxStore) query(ctx context.Context, query string, limit int, args ...interface{}) ([]*OutboxMessage, error) {
+	if limit > 0 {
+		query += " LIMIT " + strconv.Itoa(limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*OutboxMessage
+	for rows.Next() {
+		msg, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// bind 第 n 个参数的占位符（从1开始）
+func (s *SQLOutboxStore) bind(n int) string {
+	if s.dialect == SQLDialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// bindList 从第 start 个参数开始的 count 个占位符
+func (s *SQLOutboxStore) bindList(start, count int) string {
+	binds := make([]string, count)
+	for i := range binds {
+		binds[i] = s.bind(start + i)
+	}
+	return strings.Join(binds, ", ")
+}
+
+// outboxArgs 消息各列的取值（顺序与 outboxColumns 一致）
+func outboxArgs(msg *OutboxMessage) ([]interface{}, error) {
+	vars := "{}"
+	if len(msg.Vars) > 0 {
+		data, err := json.Marshal(msg.Vars)
+		if err != nil {
+			return nil, err
+		}
+		vars = string(data)
+	}
+
+	return []interface{}{
+		msg.ID, msg.Endpoint, msg.To, msg.Content, msg.Project, vars, msg.Signature, msg.Tag, msg.Status, msg.Attempts,
+		unixMilli(msg.NextAttemptAt), unixMilli(msg.LeaseUntil), msg.SendID, msg.Fee, msg.ErrorCode, msg.LastError,
+		unixMilli(msg.CreatedAt), unixMilli(msg.UpdatedAt),
+	}, nil
+}
+
+// sameOutboxRow 两条消息写入数据库的各列是否相同
+func sameOutboxRow(a, b *OutboxMessage) bool {
+	argsA, errA := outboxArgs(a)
+	argsB, errB := outboxArgs(b)
+	return errA == nil && errB == nil && reflect.DeepEqual(argsA, argsB)
+}
+
+// rowScanner *sql.Row 与 *sql.Rows 的公共接口
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanOutboxMessage 读取一行消息
+func scanOutboxMessage(row rowScanner) (*OutboxMessage, error) {
+	var (
+		msg                                             OutboxMessage
+		vars                                            string
+		nextAttemptAt, leaseUntil, createdAt, updatedAt int64
+	)
+	err := row.Scan(&msg.ID, &msg.Endpoint, &msg.To, &msg.Content, &msg.Project, &vars, &msg.Signature, &msg.Tag,
+		&msg.Status, &msg.Attempts, &nextAttemptAt, &leaseUntil, &msg.SendID, &msg.Fee, &msg.ErrorCode, &msg.LastError,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if vars != "" && vars != "{}" {
+		if err := json.Unmarshal([]byte(vars), &msg.Vars); err != nil {
+			return nil, localizedError(DefaultLocale(), msgOutboxDecodeVars, err)
+		}
+	}
+	msg.NextAttemptAt = fromUnixMilli(nextAttemptAt)
+	msg.LeaseUntil = fromUnixMilli(leaseUntil)
+	msg.CreatedAt = fromUnixMilli(createdAt)
+	msg.UpdatedAt = fromUnixMilli(updatedAt)
+	return &msg, nil
+}
+
+// unixMilli 时间转换为 Unix 毫秒，零值为0
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// fromUnixMilli Unix 毫秒转换为时间，0为零值
+func fromUnixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
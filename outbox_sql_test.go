@@ -0,0 +1,168 @@
+package submail_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+)
+
+// recordingConnector 记录执行的 SQL 语句的 database/sql 驱动：Exec 不影响任何行，查询返回 row（为空时返回空结果）
+type recordingConnector struct {
+	mu         sync.Mutex
+	statements []string
+	row        []driver.Value
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{c}, nil
+}
+func (c *recordingConnector) Driver() driver.Driver { return nil }
+
+func (c *recordingConnector) record(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statements = append(c.statements, query)
+}
+
+func (c *recordingConnector) Statements() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.statements...)
+}
+
+type recordingConn struct{ c *recordingConnector }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *recordingConn) Close() error                        { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.c.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.c.record(query)
+	if c.c.row == nil {
+		return emptyRows{}, nil
+	}
+	return &singleRow{values: c.c.row}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+// singleRow 只有一行的查询结果
+type singleRow struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *singleRow) Columns() []string { return make([]string, len(r.values)) }
+func (r *singleRow) Close() error      { return nil }
+
+func (r *singleRow) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func TestSQLOutboxStoreDialects(t *testing.T) {
+	tests := []struct {
+		dialect     string
+		bind        string
+		inlineIndex bool
+	}{
+		{"", "?", false},
+		{submail.SQLDialectMySQL, "?", true},
+		{submail.SQLDialectPostgres, "$2", false},
+	}
+	for _, tt := range tests {
+		connector := &recordingConnector{}
+		db := sql.OpenDB(connector)
+		store := submail.NewSQLOutboxStore(db, "", tt.dialect)
+
+		ctx := context.Background()
+		if err := store.CreateTable(ctx); err != nil {
+			t.Fatalf("%s: CreateTable: %v", tt.dialect, err)
+		}
+		if _, err := store.Claim(ctx, time.Now(), 5, time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("%s: Claim: %v", tt.dialect, err)
+		}
+		db.Close()
+
+		statements := connector.Statements()
+		create, claim := statements[0], statements[len(statements)-1]
+		if !strings.Contains(create, "CREATE TABLE IF NOT EXISTS submail_outbox") {
+			t.Errorf("%s: create statement = %s", tt.dialect, create)
+		}
+		if inline := strings.Contains(create, "INDEX submail_outbox_status_next"); inline != tt.inlineIndex {
+			t.Errorf("%s: inline index = %v, want %v", tt.dialect, inline, tt.inlineIndex)
+		}
+		if n := len(statements); tt.inlineIndex && n != 2 || !tt.inlineIndex && n != 3 {
+			t.Errorf("%s: executed %d statements", tt.dialect, n)
+		}
+		if !strings.Contains(claim, "next_attempt_at <= "+tt.bind) || !strings.HasSuffix(claim, " LIMIT 5") {
+			t.Errorf("%s: claim query = %s", tt.dialect, claim)
+		}
+	}
+}
+
+func TestSQLOutboxStoreNotFound(t *testing.T) {
+	db := sql.OpenDB(&recordingConnector{})
+	defer db.Close()
+	store := submail.NewSQLOutboxStore(db, "outbox", submail.SQLDialectPostgres)
+
+	ctx := context.Background()
+	if err := store.Update(ctx, &submail.OutboxMessage{ID: "missing"}); !errors.Is(err, submail.ErrOutboxNotFound) {
+		t.Fatalf("Update err = %v, want ErrOutboxNotFound", err)
+	}
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, submail.ErrOutboxNotFound) {
+		t.Fatalf("Get err = %v, want ErrOutboxNotFound", err)
+	}
+}
+
+func TestSQLOutboxStoreUnchangedRow(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	msg := &submail.OutboxMessage{
+		ID: "m1", Endpoint: submail.EndpointSMSSend, To: "13800138000", Content: "【测试】您好",
+		Status: submail.OutboxSent, Attempts: 1, NextAttemptAt: now, SendID: "send-1", Fee: 1, CreatedAt: now, UpdatedAt: now,
+	}
+	// MySQL 新值与原值相同时 RowsAffected 为0，数据库中的行与 msg 一致
+	millis := now.UnixMilli()
+	connector := &recordingConnector{row: []driver.Value{
+		msg.ID, msg.Endpoint, msg.To, msg.Content, "", "{}", "", "", msg.Status, int64(1),
+		millis, int64(0), msg.SendID, int64(1), int64(0), "", millis, millis,
+	}}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	store := submail.NewSQLOutboxStore(db, "", submail.SQLDialectMySQL)
+
+	ctx := context.Background()
+	if err := store.Update(ctx, msg); err != nil {
+		t.Fatalf("Update unchanged row: %v", err)
+	}
+	if ok, err := store.Transition(ctx, msg, submail.OutboxSent); !ok || err != nil {
+		t.Fatalf("Transition unchanged row = %v, %v, want true", ok, err)
+	}
+
+	changed := *msg
+	changed.Status = submail.OutboxDead
+	if ok, err := store.Transition(ctx, &changed, submail.OutboxSending); ok || err != nil {
+		t.Fatalf("Transition from stale status = %v, %v, want false", ok, err)
+	}
+}
@@ -0,0 +1,312 @@
+package submail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryOutboxStore 进程内发件箱存储（并发安全，进程退出后丢失，适用于测试）
+type MemoryOutboxStore struct {
+	mu       sync.Mutex
+	messages map[string]*OutboxMessage
+
+	// persist 消息变更后的持久化回调（调用时持有锁）
+	persist func(msg *OutboxMessage) error
+}
+
+// NewMemoryOutboxStore 创建进程内发件箱存储
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{messages: make(map[string]*OutboxMessage)}
+}
+
+// Add 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) Add(ctx context.Context, msg *OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.messages[msg.ID]; ok {
+		return localizedError(DefaultLocale(), msgOutboxExists, msg.ID)
+	}
+	return s.saveLocked(msg.clone())
+}
+
+// Get 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) Get(ctx context.Context, id string) (*OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.messages[id]
+	if !ok {
+		return nil, ErrOutboxNotFound
+	}
+	return msg.clone(), nil
+}
+
+// Update 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) Update(ctx context.Context, msg *OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.messages[msg.ID]; !ok {
+		return ErrOutboxNotFound
+	}
+	return s.saveLocked(msg.clone())
+}
+
+// Transition 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) Transition(ctx context.Context, msg *OutboxMessage, from string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.messages[msg.ID]
+	if !ok {
+		return false, ErrOutboxNotFound
+	}
+	if current.Status != from {
+		return false, nil
+	}
+	return true, s.saveLocked(msg.clone())
+}
+
+// Claim 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) Claim(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]*OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := s.selectLocked(limit, func(msg *OutboxMessage) bool {
+		return msg.Status == OutboxPending && !msg.NextAttemptAt.After(now)
+	})
+	claimed := make([]*OutboxMessage, 0, len(due))
+	for _, msg := range due {
+		msg = msg.clone()
+		msg.Status = OutboxSending
+		msg.LeaseUntil = leaseUntil
+		msg.UpdatedAt = now
+		if err := s.saveLocked(msg); err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, msg.clone())
+	}
+	return claimed, nil
+}
+
+// Expired 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) Expired(ctx context.Context, now time.Time, limit int) ([]*OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.selectLocked(limit, func(msg *OutboxMessage) bool {
+		return msg.Status == OutboxSending && msg.LeaseUntil.Before(now)
+	}), nil
+}
+
+// List 实现 OutboxStore 接口
+func (s *MemoryOutboxStore) List(ctx context.Context, status string, limit int) ([]*OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.selectLocked(limit, func(msg *OutboxMessage) bool {
+		return status == "" || msg.Status == status
+	}), nil
+}
+
+// selectLocked 按发送时间顺序筛选消息副本（调用方需持有锁）
+func (s *MemoryOutboxStore) selectLocked(limit int, match func(msg *OutboxMessage) bool) []*OutboxMessage {
+	var selected []*OutboxMessage
+	for _, msg := range s.messages {
+		if match(msg) {
+			selected = append(selected, msg)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if !selected[i].NextAttemptAt.Equal(selected[j].NextAttemptAt) {
+			return selected[i].NextAttemptAt.Before(selected[j].NextAttemptAt)
+		}
+		return selected[i].ID < selected[j].ID
+	})
+	if limit > 0 && len(selected) > limit {
+		selected = selected[:limit]
+	}
+	for i, msg := range selected {
+		selected[i] = msg.clone()
+	}
+	return selected
+}
+
+// saveLocked 保存消息并持久化（调用方需持有锁）
+func (s *MemoryOutboxStore) saveLocked(msg *OutboxMessage) error {
+	if s.persist != nil {
+		if err := s.persist(msg); err != nil {
+			return err
+		}
+	}
+	s.messages[msg.ID] = msg
+	return nil
+}
+
+// ===== 文件存储 =====
+
+// outboxCompactLines 日志文件至少累积的行数，超过该行数且过期快照多于有效记录时压缩
+const outboxCompactLines = 1000
+
+// FileOutboxStore 嵌入式文件发件箱存储，无需外部依赖
+// 每次变更以 JSON Lines 格式追加写入日志文件并同步到磁盘，打开时回放日志恢复状态并压缩文件，
+// 运行中日志行数超过有效记录数的两倍（且不少于1000行）时再次压缩。
+// 同一文件只能由一个进程打开；多进程部署请使用 SQLOutboxStore
+type FileOutboxStore struct {
+	*MemoryOutboxStore
+	path      string
+	file      *os.File
+	lines     int // 日志文件当前行数
+	compactAt int // 下次压缩的行数阈值
+}
+
+// NewFileOutboxStore 打开（不存在时创建）发件箱日志文件
+func NewFileOutboxStore(path string) (*FileOutboxStore, error) {
+	memory := NewMemoryOutboxStore()
+	if err := loadOutboxLog(path, memory.messages); err != nil {
+		return nil, err
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, localizedError(DefaultLocale(), msgOutboxCreateDir, err)
+		}
+	}
+	if err := compactOutboxLog(path, memory.messages); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, localizedError(DefaultLocale(), msgOutboxOpenFile, err)
+	}
+
+	s := &FileOutboxStore{MemoryOutboxStore: memory, path: path, file: file, lines: len(memory.messages)}
+	s.compactAt = max(outboxCompactLines, 2*s.lines)
+	memory.persist = s.append
+	return s, nil
+}
+
+// Close 关闭日志文件
+func (s *FileOutboxStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// append 追加一条消息快照，日志行数达到阈值时先压缩（调用时持有锁）
+func (s *FileOutboxStore) append(msg *OutboxMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if s.lines >= s.compactAt {
+		if err := s.compactLocked(); err != nil {
+			return err
+		}
+	}
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return localizedError(DefaultLocale(), msgOutboxWriteFile, err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return localizedError(DefaultLocale(), msgOutboxWriteFile, err)
+	}
+	s.lines++
+	return nil
+}
+
+// compactLocked 压缩日志文件后重新打开（调用方需持有锁）
+// 压缩失败时继续追加写入原文件，行数再翻倍后重试
+func (s *FileOutboxStore) compactLocked() error {
+	if err := s.file.Close(); err != nil {
+		return localizedError(DefaultLocale(), msgOutboxWriteFile, err)
+	}
+	compacted := compactOutboxLog(s.path, s.messages) == nil
+
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return localizedError(DefaultLocale(), msgOutboxOpenFile, err)
+	}
+	s.file = file
+	if compacted {
+		s.lines = len(s.messages)
+	}
+	s.compactAt = max(outboxCompactLines, 2*s.lines)
+	return nil
+}
+
+// loadOutboxLog 回放日志文件，同一消息以最后一条快照为准
+// 最后一行不完整（写入时进程退出）时忽略，其他行无法解析时返回错误
+func loadOutboxLog(path string, messages map[string]*OutboxMessage) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return localizedError(DefaultLocale(), msgOutboxOpenFile, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var corrupt error
+	for line := 1; scanner.Scan(); line++ {
+		if corrupt != nil {
+			return corrupt
+		}
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var msg OutboxMessage
+		err := json.Unmarshal(scanner.Bytes(), &msg)
+		if err == nil && msg.ID == "" {
+			err = errors.New("missing id")
+		}
+		if err != nil {
+			corrupt = localizedError(DefaultLocale(), msgOutboxCorruptLine, line, err)
+			continue
+		}
+		messages[msg.ID] = &msg
+	}
+	if err := scanner.Err(); err != nil {
+		return localizedError(DefaultLocale(), msgOutboxReadFile, err)
+	}
+	return nil
+}
+
+// compactOutboxLog 将当前状态写入临时文件后替换日志文件
+func compactOutboxLog(path string, messages map[string]*OutboxMessage) error {
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return localizedError(DefaultLocale(), msgOutboxCompact, err)
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, msg := range messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(append(data, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return localizedError(DefaultLocale(), msgOutboxCompact, err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return localizedError(DefaultLocale(), msgOutboxCompact, err)
+	}
+	if err := file.Close(); err != nil {
+		return localizedError(DefaultLocale(), msgOutboxCompact, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return localizedError(DefaultLocale(), msgOutboxCompact, err)
+	}
+	return nil
+}
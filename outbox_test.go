@@ -0,0 +1,269 @@
+package submail_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestOutboxDispatchSends(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	var sent []*submail.OutboxMessage
+	outbox := submail.NewOutbox(submail.NewClient(server.Config()), submail.OutboxConfig{
+		Store:  submail.NewMemoryOutboxStore(),
+		OnSent: func(msg *submail.OutboxMessage) { sent = append(sent, msg) },
+	})
+
+	ctx := context.Background()
+	id, err := outbox.EnqueueSend(ctx, &submail.SMSSendRequest{To: "13800138000", Content: "【测试】您好"})
+	if err != nil {
+		t.Fatalf("EnqueueSend: %v", err)
+	}
+	if n, err := outbox.DispatchOnce(ctx); n != 1 || err != nil {
+		t.Fatalf("DispatchOnce = %d, %v", n, err)
+	}
+
+	msg, err := outbox.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if msg.Status != submail.OutboxSent || msg.Attempts != 1 || msg.SendID == "" || msg.Fee != 1 {
+		t.Fatalf("message = %+v", msg)
+	}
+	if len(sent) != 1 || sent[0].ID != id {
+		t.Fatalf("OnSent calls = %+v", sent)
+	}
+	if msgs := server.MessagesTo("13800138000"); len(msgs) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(msgs))
+	}
+}
+
+func TestOutboxRetryDeadAndRequeue(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	recorder.Return("SMSSend", nil, submail.NewAPIError(submail.ErrPhoneFrequencyLimit, "limited"))
+
+	var dead int
+	outbox := submail.NewOutbox(recorder, submail.OutboxConfig{
+		Store: submail.NewMemoryOutboxStore(),
+		RetryPolicy: submail.RetryPolicyFunc(func(req *submail.RetryRequest, err error) (time.Duration, bool) {
+			return 0, req.Attempt < 2
+		}),
+		OnDead: func(msg *submail.OutboxMessage) { dead++ },
+	})
+
+	ctx := context.Background()
+	id, _ := outbox.EnqueueSend(ctx, &submail.SMSSendRequest{To: "13800138000", Content: "【测试】您好"})
+
+	outbox.DispatchOnce(ctx)
+	msg, _ := outbox.Get(ctx, id)
+	if msg.Status != submail.OutboxPending || msg.Attempts != 1 || msg.ErrorCode != submail.ErrPhoneFrequencyLimit {
+		t.Fatalf("after first attempt = %+v, want pending for retry", msg)
+	}
+
+	outbox.DispatchOnce(ctx)
+	msg, _ = outbox.Get(ctx, id)
+	if msg.Status != submail.OutboxDead || msg.Attempts != 2 || dead != 1 {
+		t.Fatalf("after second attempt = %+v (OnDead %d), want dead", msg, dead)
+	}
+	if err := outbox.Cancel(ctx, id); err == nil {
+		t.Fatal("Cancel accepted a dead message")
+	}
+
+	if err := outbox.Requeue(ctx, id); err != nil {
+		t.Fatalf("Requeue: %v", err)
+	}
+	msg, _ = outbox.Get(ctx, id)
+	if msg.Status != submail.OutboxPending || msg.Attempts != 0 {
+		t.Fatalf("after Requeue = %+v", msg)
+	}
+	if err := outbox.Cancel(ctx, id); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if err := outbox.Requeue(ctx, id); err == nil {
+		t.Fatal("Requeue accepted a canceled message")
+	}
+	if n := len(recorder.CallsOf("SMSSend")); n != 2 {
+		t.Fatalf("sender called %d times, want 2", n)
+	}
+}
+
+func TestOutboxSkipsSuppressedRecipients(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client, _ := suppressedClient(server, "13800138000", "13800138001")
+	project := server.AddTemplate(submail.SMSTemplate{SMSSignature: "【测试】", SMSContent: "您好@var(name)"})
+
+	var dead int
+	outbox := submail.NewOutbox(client, submail.OutboxConfig{
+		Store:  submail.NewMemoryOutboxStore(),
+		OnDead: func(msg *submail.OutboxMessage) { dead++ },
+	})
+
+	ctx := context.Background()
+	single, _ := outbox.EnqueueSend(ctx, &submail.SMSSendRequest{To: "13800138000", Content: "【测试】您好"})
+	batch, _ := outbox.EnqueueBatchXSend(ctx, &submail.SMSBatchXSendRequest{To: "13800138000,13800138001", Project: project})
+	if n, err := outbox.DispatchOnce(ctx); n != 2 || err != nil {
+		t.Fatalf("DispatchOnce = %d, %v", n, err)
+	}
+
+	for _, id := range []string{single, batch} {
+		msg, _ := outbox.Get(ctx, id)
+		if msg.Status != submail.OutboxSkipped || msg.ErrorCode != 0 {
+			t.Fatalf("message %s = %+v, want skipped", id, msg)
+		}
+	}
+	if dead != 0 {
+		t.Fatalf("OnDead called %d times, want 0", dead)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want 0", n)
+	}
+}
+
+func TestOutboxExpiredLease(t *testing.T) {
+	for _, resend := range []bool{false, true} {
+		recorder := submailtest.NewRecorder()
+		store := submail.NewMemoryOutboxStore()
+		outbox := submail.NewOutbox(recorder, submail.OutboxConfig{Store: store, ResendExpired: resend})
+
+		ctx := context.Background()
+		id, _ := outbox.EnqueueSend(ctx, &submail.SMSSendRequest{To: "13800138000", Content: "【测试】您好"})
+		// 模拟进程在发送过程中退出：消息被领取后租约已过期
+		now := time.Now()
+		store.Claim(ctx, now, 10, now.Add(-time.Second))
+
+		outbox.DispatchOnce(ctx)
+		msg, _ := outbox.Get(ctx, id)
+		want, calls := submail.OutboxDead, 0
+		if resend {
+			want, calls = submail.OutboxSent, 1
+		}
+		if msg.Status != want || len(recorder.Calls()) != calls {
+			t.Errorf("ResendExpired=%v: status %s after %d sends, want %s after %d", resend, msg.Status, len(recorder.Calls()), want, calls)
+		}
+	}
+}
+
+func TestOutboxShutdownReleasesClaims(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	store := submail.NewMemoryOutboxStore()
+	outbox := submail.NewOutbox(recorder, submail.OutboxConfig{Store: store, Workers: 1})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		outbox.EnqueueSend(ctx, &submail.SMSSendRequest{To: "13800138000", Content: "【测试】您好"})
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if n, _ := outbox.DispatchOnce(canceled); n != 3 {
+		t.Fatalf("claimed %d messages, want 3", n)
+	}
+
+	// 已领取但未发出的消息放回待发送队列，且不计入发送次数
+	pending, _ := store.List(ctx, submail.OutboxPending, 0)
+	if len(pending) != 3 {
+		t.Fatalf("%d messages pending after shutdown, want 3", len(pending))
+	}
+	for _, msg := range pending {
+		if msg.Attempts != 0 || !msg.LeaseUntil.IsZero() {
+			t.Fatalf("released message = %+v", msg)
+		}
+	}
+	if n := len(recorder.Calls()); n != 0 {
+		t.Fatalf("sender called %d times after shutdown, want 0", n)
+	}
+}
+
+func TestFileOutboxStoreReplaysLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox", "outbox.log")
+	store, err := submail.NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	msg := &submail.OutboxMessage{ID: "m1", Endpoint: submail.EndpointSMSSend, To: "13800138000", Status: submail.OutboxPending, NextAttemptAt: now}
+	if err := store.Add(ctx, msg); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	msg.Status = submail.OutboxDead
+	msg.LastError = "failed"
+	if err := store.Update(ctx, msg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	store.Close()
+
+	// 写入时进程退出留下的不完整行应被忽略
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	file.WriteString(`{"id":"m2","status":"pen`)
+	file.Close()
+
+	store, err = submail.NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get(ctx, "m1")
+	if err != nil || got.Status != submail.OutboxDead || got.LastError != "failed" {
+		t.Fatalf("Get = %+v, %v", got, err)
+	}
+	if all, _ := store.List(ctx, "", 0); len(all) != 1 {
+		t.Fatalf("List = %d messages, want 1", len(all))
+	}
+}
+
+func TestFileOutboxStoreRejectsCorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	os.WriteFile(path, []byte(`{"id":"m1","status":"pending"}`+"\n"+`not json`+"\n"+`{"id":"m2","status":"pending"}`+"\n"), 0o644)
+
+	if store, err := submail.NewFileOutboxStore(path); err == nil || !strings.Contains(err.Error(), "2") {
+		if store != nil {
+			store.Close()
+		}
+		t.Fatalf("NewFileOutboxStore err = %v, want corrupt line 2", err)
+	}
+}
+
+func TestFileOutboxStoreCompactsWhileRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	store, err := submail.NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	msg := &submail.OutboxMessage{ID: "m1", Endpoint: submail.EndpointSMSSend, To: "13800138000", Status: submail.OutboxPending}
+	store.Add(ctx, msg)
+	for i := 1; i <= 1500; i++ {
+		msg.Attempts = i
+		if err := store.Update(ctx, msg); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if lines := strings.Count(string(data), "\n"); lines >= 1000 {
+		t.Fatalf("log has %d lines after 1501 writes, want it compacted", lines)
+	}
+
+	reopened, err := submail.NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	if got, err := reopened.Get(ctx, "m1"); err != nil || got.Attempts != 1500 {
+		t.Fatalf("Get = %+v, %v", got, err)
+	}
+}
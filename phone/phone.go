@@ -0,0 +1,284 @@
+// Package phone 提供手机号码的解析、规范化与校验
+//
+// 支持中国大陆11位手机号（可带 +86、0086、86 前缀）以及 E.164 格式的国际号码，
+// 可识别中国大陆手机号的运营商（与 submail.SMSReportOperators 的字段一致）、虚拟运营商号段及国家/地区，
+// 通过 RegisterSegments 加载号段数据后还可识别省份与城市。
+//
+//	n, err := phone.Parse("+86 138-0013-8000")
+//	n.E164          // +8613800138000
+//	n.String()      // 13800138000（SUBMAIL API 使用的格式）
+//	n.Carrier       // china_mobile
+package phone
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// 运营商，取值与 submail.SMSReportOperators 的 JSON 字段名一致
+const (
+	CarrierChinaMobile   = "china_mobile"   // 中国移动
+	CarrierChinaUnicom   = "china_unicom"   // 中国联通
+	CarrierChinaTelecom  = "china_telecom"  // 中国电信
+	CarrierChinaBroadnet = "china_broadnet" // 中国广电（192号段，SMSReportOperators 中无对应字段）
+)
+
+// MainlandCountryCode 中国大陆国际电话区号
+const MainlandCountryCode = "86"
+
+// 号码无效的原因（ParseError.Reason）
+const (
+	ReasonEmpty               = "empty"                // 号码为空
+	ReasonInvalidCharacter    = "invalid_character"    // 包含无效字符（Detail 为该字符）
+	ReasonNotMainland         = "not_mainland"         // 不是中国大陆手机号，且没有 + 或 00 国际前缀
+	ReasonInternationalLength = "international_length" // 国际号码长度不在8到15位之间
+	ReasonCountryCode         = "country_code"         // 国际电话区号以0开头
+	ReasonTooShort            = "too_short"            // 去掉国际电话区号后号码过短
+	ReasonMainlandLength      = "mainland_length"      // 中国大陆手机号不是以1开头的11位号码
+	ReasonUnknownPrefix       = "unknown_prefix"       // 号段未收录（Detail 为3位号段）
+)
+
+// defaultMessages 默认（中文）错误信息，%s 为 Detail
+var defaultMessages = map[string]string{
+	"":                        "无效的手机号码",
+	ReasonEmpty:               "号码为空",
+	ReasonInvalidCharacter:    "包含无效字符 %s",
+	ReasonNotMainland:         "不是中国大陆手机号，国际号码需以 + 或 00 开头",
+	ReasonInternationalLength: "国际号码长度应为8到15位",
+	ReasonCountryCode:         "国际电话区号不能以0开头",
+	ReasonTooShort:            "号码长度不足",
+	ReasonMainlandLength:      "中国大陆手机号应为以1开头的11位号码",
+	ReasonUnknownPrefix:       "号段 %s 不是有效的中国大陆手机号段",
+}
+
+// localizer 错误信息生成函数，为nil时使用默认信息
+var localizer atomic.Pointer[func(e *ParseError) string]
+
+// SetLocalizer 设置错误信息的生成函数（如按语言生成错误信息），fn 为nil时恢复默认的中文信息
+// ErrInvalidNumber 调用时 e 的 Reason 为空；导入 submail 包时会设置为使用 submail.DefaultLocale 对应的消息目录
+func SetLocalizer(fn func(e *ParseError) string) {
+	if fn == nil {
+		localizer.Store(nil)
+		return
+	}
+	localizer.Store(&fn)
+}
+
+// DefaultMessage 默认（中文）错误信息，可在自定义的 SetLocalizer 函数中作为后备
+func DefaultMessage(e *ParseError) string {
+	message := defaultMessages[e.Reason]
+	if strings.Contains(message, "%s") {
+		message = strings.Replace(message, "%s", e.Detail, 1)
+	}
+	if e.Reason == "" {
+		return message
+	}
+	if e.Number == "" {
+		return defaultMessages[""] + ": " + message
+	}
+	return defaultMessages[""] + " " + e.Number + ": " + message
+}
+
+// invalidNumber ErrInvalidNumber 的类型，错误信息随 SetLocalizer 变化
+type invalidNumber struct{}
+
+func (invalidNumber) Error() string {
+	return message(&ParseError{})
+}
+
+// ErrInvalidNumber 无效的手机号码，可使用 errors.Is 判断
+var ErrInvalidNumber error = invalidNumber{}
+
+// ParseError 号码解析错误（errors.Is(err, ErrInvalidNumber) 为 true）
+type ParseError struct {
+	Number string // 原始输入
+	Reason string // 原因：ReasonEmpty、ReasonInvalidCharacter 等，可据此生成其他语言的错误信息
+	Detail string // 原因的补充信息（如无效字符、号段）
+}
+
+func (e *ParseError) Error() string {
+	return message(e)
+}
+
+// Is 支持 errors.Is(err, ErrInvalidNumber)
+func (e *ParseError) Is(target error) bool {
+	return target == ErrInvalidNumber
+}
+
+// message 生成错误信息
+func message(e *ParseError) string {
+	if fn := localizer.Load(); fn != nil {
+		return (*fn)(e)
+	}
+	return DefaultMessage(e)
+}
+
+// Number 解析后的手机号码
+type Number struct {
+	Raw         string // 原始输入
+	E164        string // E.164 格式，如 +8613800138000
+	CountryCode string // 国际电话区号，如 86；区号未收录时为空
+	National    string // 去掉区号后的号码，如 13800138000；区号未收录时为去掉 + 的完整号码
+	Region      string // 国家/地区代码（ISO 3166-1），如 CN、HK、US；未收录时为空
+	Carrier     string // 运营商（仅中国大陆手机号）
+	Virtual     bool   // 是否为虚拟运营商号段（仅中国大陆手机号）
+	Unlisted    bool   // 号段未收录（仅 ParseLenient 返回），此时 Carrier 为空
+	Province    string // 省份（需通过 RegisterSegments 加载号段数据）
+	City        string // 城市（需通过 RegisterSegments 加载号段数据）
+}
+
+// IsMainland 是否为中国大陆手机号
+func (n *Number) IsMainland() bool {
+	return n.CountryCode == MainlandCountryCode
+}
+
+// String 返回 SUBMAIL API 使用的格式：中国大陆手机号为11位号码，国际号码为 E.164 格式
+func (n *Number) String() string {
+	if n.IsMainland() {
+		return n.National
+	}
+	return n.E164
+}
+
+// Parse 解析手机号码
+// 忽略空格、短横线、括号和点；以 + 或 00 开头的视为国际格式，11位以1开头的视为中国大陆手机号，
+// 86 加11位手机号视为带区号的中国大陆手机号，其他格式返回 *ParseError
+func Parse(raw string) (*Number, error) {
+	return parse(raw, false)
+}
+
+// ParseLenient 与 Parse 相同，但13至19开头的中国大陆手机号号段未收录时不返回错误，而是返回 Unlisted 为 true 的号码
+// 号段表需随运营商放号手动更新，用于避免新号段被误判为无效号码
+func ParseLenient(raw string) (*Number, error) {
+	return parse(raw, true)
+}
+
+// parse 解析手机号码，lenient 为 true 时接受未收录的中国大陆手机号段
+func parse(raw string, lenient bool) (*Number, error) {
+	digits, international, err := clean(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case international:
+	case len(digits) == 11 && digits[0] == '1':
+		return parseMainland(raw, digits, lenient)
+	case len(digits) == 13 && strings.HasPrefix(digits, MainlandCountryCode+"1"):
+		return parseMainland(raw, digits[2:], lenient)
+	default:
+		return nil, &ParseError{Number: raw, Reason: ReasonNotMainland}
+	}
+
+	if len(digits) < 8 || len(digits) > 15 {
+		return nil, &ParseError{Number: raw, Reason: ReasonInternationalLength}
+	}
+	if digits[0] == '0' {
+		return nil, &ParseError{Number: raw, Reason: ReasonCountryCode}
+	}
+
+	if strings.HasPrefix(digits, MainlandCountryCode) {
+		return parseMainland(raw, digits[2:], lenient)
+	}
+
+	n := &Number{Raw: raw, E164: "+" + digits, National: digits}
+	if code, region, ok := lookupCountry(digits); ok {
+		n.CountryCode = code
+		n.National = digits[len(code):]
+		n.Region = region
+		if len(n.National) < 4 {
+			return nil, &ParseError{Number: raw, Reason: ReasonTooShort}
+		}
+	}
+	return n, nil
+}
+
+// parseMainland 解析中国大陆手机号（national 为去掉区号后的号码）
+func parseMainland(raw, national string, lenient bool) (*Number, error) {
+	if len(national) != 11 || national[0] != '1' {
+		return nil, &ParseError{Number: raw, Reason: ReasonMainlandLength}
+	}
+
+	info, ok := lookupMobilePrefix(national)
+	unlisted := !ok && lenient && national[1] >= '3'
+	if !ok && !unlisted {
+		return nil, &ParseError{Number: raw, Reason: ReasonUnknownPrefix, Detail: national[:3]}
+	}
+
+	n := &Number{
+		Raw:         raw,
+		E164:        "+" + MainlandCountryCode + national,
+		CountryCode: MainlandCountryCode,
+		National:    national,
+		Region:      "CN",
+		Carrier:     info.carrier,
+		Virtual:     info.virtual,
+		Unlisted:    unlisted,
+	}
+	if segment, ok := lookupSegment(national); ok {
+		n.Province = segment.Province
+		n.City = segment.City
+	}
+	return n, nil
+}
+
+// clean 去掉分隔符并识别国际前缀，返回纯数字号码
+func clean(raw string) (digits string, international bool, err error) {
+	s := strings.TrimSpace(raw)
+	s = strings.Replace(s, "＋", "+", 1)
+
+	if strings.HasPrefix(s, "+") {
+		international = true
+		s = s[1:]
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.' || r == '\t':
+		default:
+			return "", false, &ParseError{Number: raw, Reason: ReasonInvalidCharacter, Detail: string(r)}
+		}
+	}
+
+	digits = b.String()
+	if digits == "" {
+		return "", false, &ParseError{Number: raw, Reason: ReasonEmpty}
+	}
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+	return digits, international, nil
+}
+
+// ===== 便捷函数 =====
+
+// Normalize 解析并返回 E.164 格式的号码
+func Normalize(raw string) (string, error) {
+	n, err := Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	return n.E164, nil
+}
+
+// IsValid 判断号码是否有效
+func IsValid(raw string) bool {
+	_, err := Parse(raw)
+	return err == nil
+}
+
+// IsMainlandMobile 判断是否为有效的中国大陆手机号
+func IsMainlandMobile(raw string) bool {
+	n, err := Parse(raw)
+	return err == nil && n.IsMainland()
+}
+
+// IsInternational 判断是否为有效的国际号码（非中国大陆）
+func IsInternational(raw string) bool {
+	n, err := Parse(raw)
+	return err == nil && !n.IsMainland()
+}
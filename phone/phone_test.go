@@ -0,0 +1,139 @@
+package phone_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw      string
+		e164     string
+		str      string
+		region   string
+		carrier  string
+		virtual  bool
+		mainland bool
+	}{
+		{"13800138000", "+8613800138000", "13800138000", "CN", phone.CarrierChinaMobile, false, true},
+		{"+86 138-0013-8000", "+8613800138000", "13800138000", "CN", phone.CarrierChinaMobile, false, true},
+		{"0086 13800138000", "+8613800138000", "13800138000", "CN", phone.CarrierChinaMobile, false, true},
+		{"8613800138000", "+8613800138000", "13800138000", "CN", phone.CarrierChinaMobile, false, true},
+		{"18612345678", "+8618612345678", "18612345678", "CN", phone.CarrierChinaUnicom, false, true},
+		{"1700 123 4567", "+8617001234567", "17001234567", "CN", phone.CarrierChinaTelecom, true, true},
+		{"19212345678", "+8619212345678", "19212345678", "CN", phone.CarrierChinaBroadnet, false, true},
+		{"+852 9123 4567", "+85291234567", "+85291234567", "HK", "", false, false},
+		{"+1 (415) 555-2671", "+14155552671", "+14155552671", "US", "", false, false},
+		{"＋44 20 7946 0958", "+442079460958", "+442079460958", "GB", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			n, err := phone.Parse(tt.raw)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if n.E164 != tt.e164 || n.String() != tt.str || n.Region != tt.region ||
+				n.Carrier != tt.carrier || n.Virtual != tt.virtual || n.IsMainland() != tt.mainland {
+				t.Fatalf("Parse = %+v", n)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, raw := range []string{"", "12345", "10012345678", "1380013800a", "+0123456789", "+8612345", "020-12345678", "+123"} {
+		t.Run(raw, func(t *testing.T) {
+			_, err := phone.Parse(raw)
+			if !errors.Is(err, phone.ErrInvalidNumber) {
+				t.Fatalf("err = %v, want ErrInvalidNumber", err)
+			}
+			var parseErr *phone.ParseError
+			if !errors.As(err, &parseErr) || parseErr.Number != raw {
+				t.Fatalf("ParseError = %+v", parseErr)
+			}
+		})
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	if got, err := phone.Normalize("138 0013 8000"); err != nil || got != "+8613800138000" {
+		t.Fatalf("Normalize = %q, %v", got, err)
+	}
+	if !phone.IsMainlandMobile("+8613800138000") || phone.IsMainlandMobile("+85291234567") {
+		t.Fatal("IsMainlandMobile")
+	}
+	if !phone.IsInternational("+85291234567") || phone.IsInternational("0086 13800138000") || phone.IsInternational("abc") {
+		t.Fatal("IsInternational")
+	}
+	if phone.IsValid("12345") || !phone.IsValid("13800138000") {
+		t.Fatal("IsValid")
+	}
+}
+
+func TestRegisterSegments(t *testing.T) {
+	phone.RegisterSegments(map[string]phone.Segment{
+		"139":     {Province: "未知"},
+		"1390571": {Province: "浙江", City: "杭州"},
+	})
+
+	n, err := phone.Parse("13905710000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Province != "浙江" || n.City != "杭州" {
+		t.Fatalf("segment = %s %s, want the longest prefix match", n.Province, n.City)
+	}
+	if n, _ := phone.Parse("13912340000"); n.Province != "未知" {
+		t.Fatalf("segment = %q, want the 3-digit fallback", n.Province)
+	}
+}
+
+func TestParseErrorReason(t *testing.T) {
+	tests := map[string]struct{ reason, detail string }{
+		"":            {phone.ReasonEmpty, ""},
+		"1380013800a": {phone.ReasonInvalidCharacter, "a"},
+		"12345":       {phone.ReasonNotMainland, ""},
+		"10012345678": {phone.ReasonUnknownPrefix, "100"},
+		"14612345678": {phone.ReasonUnknownPrefix, "146"},
+		"+0123456789": {phone.ReasonCountryCode, ""},
+	}
+	for raw, want := range tests {
+		_, err := phone.Parse(raw)
+		var parseErr *phone.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Reason != want.reason || parseErr.Detail != want.detail {
+			t.Errorf("Parse(%q) err = %+v, want reason %s detail %q", raw, parseErr, want.reason, want.detail)
+		}
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	n, err := phone.ParseLenient("+86 146 1234 5678")
+	if err != nil {
+		t.Fatalf("ParseLenient: %v", err)
+	}
+	if !n.Unlisted || n.Carrier != "" || n.String() != "14612345678" {
+		t.Fatalf("ParseLenient = %+v", n)
+	}
+	if n, _ := phone.ParseLenient("13800138000"); n.Unlisted || n.Carrier != phone.CarrierChinaMobile {
+		t.Fatalf("listed prefix = %+v", n)
+	}
+	// 10、11、12 开头的号码不是手机号，宽松模式下同样无效
+	if _, err := phone.ParseLenient("12012345678"); !errors.Is(err, phone.ErrInvalidNumber) {
+		t.Fatalf("ParseLenient(120...) err = %v", err)
+	}
+}
+
+func TestSetLocalizer(t *testing.T) {
+	_, err := phone.Parse("12345")
+	if want := "无效的手机号码 12345: 不是中国大陆手机号，国际号码需以 + 或 00 开头"; err.Error() != want {
+		t.Fatalf("default message = %q, want %q", err.Error(), want)
+	}
+
+	phone.SetLocalizer(func(e *phone.ParseError) string { return "invalid phone number (" + e.Reason + ")" })
+	defer phone.SetLocalizer(nil)
+	if err.Error() != "invalid phone number (not_mainland)" || phone.ErrInvalidNumber.Error() != "invalid phone number ()" {
+		t.Fatalf("localized messages = %q / %q", err.Error(), phone.ErrInvalidNumber.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package phone
+
+import "sync"
+
+// prefixInfo 中国大陆手机号段信息
+type prefixInfo struct {
+	carrier string
+	virtual bool
+}
+
+// mobilePrefixes 中国大陆手机号段（3位号段，部分号段按4位细分）
+var mobilePrefixes = map[string]prefixInfo{
+	// 中国移动
+	"135": {carrier: CarrierChinaMobile}, "136": {carrier: CarrierChinaMobile}, "137": {carrier: CarrierChinaMobile},
+	"138": {carrier: CarrierChinaMobile}, "139": {carrier: CarrierChinaMobile}, "147": {carrier: CarrierChinaMobile},
+	"150": {carrier: CarrierChinaMobile}, "151": {carrier: CarrierChinaMobile}, "152": {carrier: CarrierChinaMobile},
+	"157": {carrier: CarrierChinaMobile}, "158": {carrier: CarrierChinaMobile}, "159": {carrier: CarrierChinaMobile},
+	"172": {carrier: CarrierChinaMobile}, "178": {carrier: CarrierChinaMobile}, "182": {carrier: CarrierChinaMobile},
+	"183": {carrier: CarrierChinaMobile}, "184": {carrier: CarrierChinaMobile}, "187": {carrier: CarrierChinaMobile},
+	"188": {carrier: CarrierChinaMobile}, "195": {carrier: CarrierChinaMobile}, "197": {carrier: CarrierChinaMobile},
+	"198":  {carrier: CarrierChinaMobile},
+	"1340": {carrier: CarrierChinaMobile}, "1341": {carrier: CarrierChinaMobile}, "1342": {carrier: CarrierChinaMobile},
+	"1343": {carrier: CarrierChinaMobile}, "1344": {carrier: CarrierChinaMobile}, "1345": {carrier: CarrierChinaMobile},
+	"1346": {carrier: CarrierChinaMobile}, "1347": {carrier: CarrierChinaMobile}, "1348": {carrier: CarrierChinaMobile},
+	"165":  {carrier: CarrierChinaMobile, virtual: true},
+	"1703": {carrier: CarrierChinaMobile, virtual: true}, "1705": {carrier: CarrierChinaMobile, virtual: true},
+	"1706": {carrier: CarrierChinaMobile, virtual: true},
+
+	// 中国联通
+	"130": {carrier: CarrierChinaUnicom}, "131": {carrier: CarrierChinaUnicom}, "132": {carrier: CarrierChinaUnicom},
+	"145": {carrier: CarrierChinaUnicom}, "155": {carrier: CarrierChinaUnicom}, "156": {carrier: CarrierChinaUnicom},
+	"166": {carrier: CarrierChinaUnicom}, "175": {carrier: CarrierChinaUnicom}, "176": {carrier: CarrierChinaUnicom},
+	"185": {carrier: CarrierChinaUnicom}, "186": {carrier: CarrierChinaUnicom}, "196": {carrier: CarrierChinaUnicom},
+	"167":  {carrier: CarrierChinaUnicom, virtual: true},
+	"171":  {carrier: CarrierChinaUnicom, virtual: true},
+	"1704": {carrier: CarrierChinaUnicom, virtual: true}, "1707": {carrier: CarrierChinaUnicom, virtual: true},
+	"1708": {carrier: CarrierChinaUnicom, virtual: true}, "1709": {carrier: CarrierChinaUnicom, virtual: true},
+
+	// 中国电信
+	"133": {carrier: CarrierChinaTelecom}, "149": {carrier: CarrierChinaTelecom}, "153": {carrier: CarrierChinaTelecom},
+	"173": {carrier: CarrierChinaTelecom}, "177": {carrier: CarrierChinaTelecom}, "180": {carrier: CarrierChinaTelecom},
+	"181": {carrier: CarrierChinaTelecom}, "189": {carrier: CarrierChinaTelecom}, "190": {carrier: CarrierChinaTelecom},
+	"191": {carrier: CarrierChinaTelecom}, "193": {carrier: CarrierChinaTelecom}, "199": {carrier: CarrierChinaTelecom},
+	"1349": {carrier: CarrierChinaTelecom},
+	"162":  {carrier: CarrierChinaTelecom, virtual: true},
+	"1700": {carrier: CarrierChinaTelecom, virtual: true}, "1701": {carrier: CarrierChinaTelecom, virtual: true},
+	"1702": {carrier: CarrierChinaTelecom, virtual: true},
+
+	// 中国广电
+	"192": {carrier: CarrierChinaBroadnet},
+}
+
+// lookupMobilePrefix 查找号段信息，4位号段优先
+func lookupMobilePrefix(national string) (prefixInfo, bool) {
+	if info, ok := mobilePrefixes[national[:4]]; ok {
+		return info, true
+	}
+	info, ok := mobilePrefixes[national[:3]]
+	return info, ok
+}
+
+// countryCodes 国际电话区号与国家/地区代码（ISO 3166-1）
+// 区号为1的北美地区统一记为 US
+var countryCodes = map[string]string{
+	"1": "US", "7": "RU",
+	"20": "EG", "27": "ZA", "30": "GR", "31": "NL", "32": "BE", "33": "FR", "34": "ES", "36": "HU",
+	"39": "IT", "40": "RO", "41": "CH", "43": "AT", "44": "GB", "45": "DK", "46": "SE", "47": "NO",
+	"48": "PL", "49": "DE", "51": "PE", "52": "MX", "54": "AR", "55": "BR", "56": "CL", "57": "CO",
+	"60": "MY", "61": "AU", "62": "ID", "63": "PH", "64": "NZ", "65": "SG", "66": "TH", "81": "JP",
+	"82": "KR", "84": "VN", "90": "TR", "91": "IN", "92": "PK", "93": "AF", "94": "LK", "95": "MM",
+	"98":  "IR",
+	"234": "NG", "254": "KE", "351": "PT", "353": "IE", "358": "FI", "380": "UA", "852": "HK",
+	"853": "MO", "855": "KH", "856": "LA", "880": "BD", "886": "TW", "960": "MV", "966": "SA",
+	"971": "AE", "972": "IL", "974": "QA", "977": "NP",
+}
+
+// lookupCountry 按最长匹配查找国际电话区号（E.164 区号为前缀码，不会出现歧义）
+func lookupCountry(digits string) (code, region string, ok bool) {
+	for n := 3; n >= 1; n-- {
+		if len(digits) <= n {
+			continue
+		}
+		if region, ok := countryCodes[digits[:n]]; ok {
+			return digits[:n], region, true
+		}
+	}
+	return "", "", false
+}
+
+// ===== 号段归属地 =====
+
+// Segment 号段归属地
+type Segment struct {
+	Province string // 省份
+	City     string // 城市
+}
+
+var (
+	segmentsMu sync.RWMutex
+	segments   = make(map[string]Segment)
+)
+
+// RegisterSegments 加载号段归属地数据，键为号码前缀（通常为7位号段，如 1380013），可多次调用追加或覆盖
+// SDK 不内置归属地数据库，可按需从运营商或第三方号段数据导入
+func RegisterSegments(data map[string]Segment) {
+	segmentsMu.Lock()
+	defer segmentsMu.Unlock()
+	for prefix, segment := range data {
+		segments[prefix] = segment
+	}
+}
+
+// lookupSegment 按最长前缀查找号段归属地
+func lookupSegment(national string) (Segment, bool) {
+	segmentsMu.RLock()
+	defer segmentsMu.RUnlock()
+
+	if len(segments) == 0 {
+		return Segment{}, false
+	}
+	for n := len(national); n >= 3; n-- {
+		if segment, ok := segments[national[:n]]; ok {
+			return segment, true
+		}
+	}
+	return Segment{}, false
+}
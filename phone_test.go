@@ -0,0 +1,127 @@
+package submail_test
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestSendNormalizesRecipients(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "+86 138-0013-8000", Content: "【测试】验证码1234"}); err != nil {
+		t.Fatalf("SMSSend: %v", err)
+	}
+	if _, err := client.SMSBatchSend(&submail.SMSBatchSendRequest{To: "0086 13800138001, +852 9123 4567", Content: "【测试】您好"}); err != nil {
+		t.Fatalf("SMSBatchSend: %v", err)
+	}
+	if _, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{Content: "【测试】您好", Multi: []submail.SMSMultiItem{{To: "8613800138002"}}}); err != nil {
+		t.Fatalf("SMSMultiSend: %v", err)
+	}
+
+	for _, to := range []string{"13800138000", "13800138001", "+85291234567", "13800138002"} {
+		if len(server.MessagesTo(to)) != 1 {
+			t.Errorf("no message sent to normalized number %s (messages: %+v)", to, server.Messages())
+		}
+	}
+}
+
+func TestSendRejectsInvalidRecipients(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	_, err := client.SMSBatchSend(&submail.SMSBatchSendRequest{To: "13800138000,12345", Content: "【测试】您好"})
+	if !submail.IsValidationError(err) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want 0", n)
+	}
+
+	// 关闭校验时原样发送
+	config := server.Config()
+	config.SkipPhoneValidation = true
+	if _, err := submail.NewClient(config).SMSSend(&submail.SMSSendRequest{To: "12345", Content: "【测试】您好"}); err != nil {
+		t.Fatalf("SMSSend without validation: %v", err)
+	}
+	if len(server.MessagesTo("12345")) != 1 {
+		t.Fatal("raw recipient was not sent as-is")
+	}
+}
+
+func TestSendWarnsOnUnlistedPrefix(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	var buf bytes.Buffer
+	config := server.Config()
+	config.Logger = log.New(&buf, "", 0)
+	client := submail.NewClient(config)
+
+	// 146 不在号段表中：记录警告后以规范化的格式发送
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "+86 146 1234 5678", Content: "【测试】您好"}); err != nil {
+		t.Fatalf("SMSSend: %v", err)
+	}
+	if len(server.MessagesTo("14612345678")) != 1 {
+		t.Fatalf("unlisted number not sent (messages: %+v)", server.Messages())
+	}
+	if !strings.Contains(buf.String(), "146") {
+		t.Fatalf("log = %q, want a warning about prefix 146", buf.String())
+	}
+}
+
+func TestInvalidPhoneMessageLocalized(t *testing.T) {
+	config := submail.Config{AppID: "test-app", AppKey: "test-key", Locale: submail.LocaleEN}
+	_, err := submail.NewClient(config).SMSSend(&submail.SMSSendRequest{To: "1380013800a", Content: "【测试】您好"})
+	if want := "invalid phone number 1380013800a: contains invalid character a"; err == nil || err.Error() != want {
+		t.Fatalf("err = %v, want %q", err, want)
+	}
+}
+
+func TestIsInternationalNumber(t *testing.T) {
+	tests := map[string]bool{
+		"13800138000":      false,
+		"+8613800138000":   false,
+		"008613800138000":  false,
+		"+85291234567":     true,
+		"00 852 9123 4567": true,
+		"1380013800a":      false,
+		"12345":            false,
+	}
+	for in, want := range tests {
+		if got := submail.IsInternationalNumber(in); got != want {
+			t.Errorf("IsInternationalNumber(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestDryRunUnionSendUsesPhoneParsing(t *testing.T) {
+	ch := make(chan submail.DryRunMessage, 1)
+	client := submail.NewClient(submail.Config{
+		AppID:               "test-app",
+		AppKey:              "test-key",
+		DryRun:              true,
+		DryRunSink:          submail.NewChannelDryRunSink(ch),
+		SkipPhoneValidation: true,
+	})
+
+	// 86 加11位号码是中国大陆手机号，演练输出与实际发送一致使用国内短信正文
+	_, err := client.SMSUnionSend(&submail.SMSUnionSendRequest{
+		To:           "8613800138000",
+		Content:      "【测试】您好",
+		InterContent: "[Test] Hello",
+	})
+	if err != nil {
+		t.Fatalf("SMSUnionSend: %v", err)
+	}
+	if msg := <-ch; msg.Content != "【测试】您好" {
+		t.Fatalf("dry-run content = %q, want domestic content", msg.Content)
+	}
+}
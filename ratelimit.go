@@ -0,0 +1,262 @@
+package submail
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+// ErrRateLimited 超出客户端发送频率限制，可使用 errors.Is 判断
+var ErrRateLimited error = sentinelError(msgErrRateLimited)
+
+// RateLimitError 客户端限流错误（请求未发出）
+type RateLimitError struct {
+	Phone      string        // 超出限制的手机号（全局限流时为空）
+	RetryAfter time.Duration // 预计可重试的等待时间
+
+	locale string // 错误信息语言
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Phone != "" {
+		return localizef(e.locale, msgPhoneRateLimited, e.Phone, e.RetryAfter.Round(time.Millisecond))
+	}
+	return localizef(e.locale, msgRateLimited, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is 支持 errors.Is(err, ErrRateLimited)
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RateLimitRule 令牌桶限流规则：每 Window 时间内最多 Limit 次
+type RateLimitRule struct {
+	Limit  int           // 窗口内允许的次数
+	Window time.Duration // 时间窗口，如 time.Minute、time.Hour、24*time.Hour
+}
+
+// RateLimitResult 限流检查结果
+type RateLimitResult struct {
+	Allowed    bool          // 是否允许（允许时已扣减令牌）
+	Key        string        // 被拒绝的键（允许时为空）
+	RetryAfter time.Duration // 被拒绝时预计的等待时间
+}
+
+// RateLimitStore 限流计数存储
+// 多实例部署时可基于 Redis 等共享存储实现，以便多个进程共享同一份计数
+type RateLimitStore interface {
+	// Take 为每个键按所有规则各扣减一个令牌（同一键出现多次则扣减多次）
+	// 必须是原子的：任一键的任一规则令牌不足时，不扣减任何令牌并返回被拒绝的键
+	Take(ctx context.Context, keys []string, rules []RateLimitRule) (*RateLimitResult, error)
+}
+
+// RateLimiter 客户端限流配置
+// 在请求发出之前拦截，避免浪费调用后才收到 115（手机号请求超限）、901（日配额用尽）等错误
+type RateLimiter struct {
+	RequestsPerSecond float64         // 全局每秒请求数（0 表示不限制）
+	Burst             int             // 全局突发请求数（默认为 RequestsPerSecond 向上取整）
+	PhoneRules        []RateLimitRule // 单个手机号的发送频率限制（可设置多条，如每分钟1条、每小时5条、每天10条）
+	MaxWait           time.Duration   // 超出限制时的最长等待时间，0 表示立即返回 ErrRateLimited
+	Store             RateLimitStore  // 计数存储（默认进程内存储）
+	KeyPrefix         string          // 存储键前缀（默认使用 AppID）
+}
+
+// newRateLimiter 复制限流配置并补充默认值
+func newRateLimiter(config *RateLimiter, appID string) *RateLimiter {
+	if config == nil {
+		return nil
+	}
+
+	limiter := *config
+	limiter.PhoneRules = append([]RateLimitRule(nil), config.PhoneRules...)
+	if limiter.Store == nil {
+		limiter.Store = NewMemoryRateLimitStore()
+	}
+	if limiter.KeyPrefix == "" {
+		limiter.KeyPrefix = appID
+	}
+	if limiter.RequestsPerSecond > 0 && limiter.Burst <= 0 {
+		limiter.Burst = int(math.Ceil(limiter.RequestsPerSecond))
+	}
+	return &limiter
+}
+
+// globalRule 全局限流规则
+func (l *RateLimiter) globalRule() RateLimitRule {
+	return RateLimitRule{
+		Limit:  l.Burst,
+		Window: time.Duration(float64(l.Burst) / l.RequestsPerSecond * float64(time.Second)),
+	}
+}
+
+// waitGlobal 全局请求限流
+func (c *Client) waitGlobal(ctx context.Context) error {
+	l := c.rateLimiter
+	if l == nil || l.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	keys := []string{l.KeyPrefix + ":global"}
+	return c.takeRateLimit(ctx, keys, []RateLimitRule{l.globalRule()}, nil)
+}
+
+// waitRecipients 按手机号限流
+func (c *Client) waitRecipients(ctx context.Context, recipients []string) error {
+	l := c.rateLimiter
+	if l == nil || len(l.PhoneRules) == 0 || len(recipients) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(recipients))
+	phones := make(map[string]string, len(recipients))
+	for _, recipient := range recipients {
+		// 号码统一为 E.164 格式，避免同一号码的不同写法各自使用独立的令牌桶
+		number := strings.TrimSpace(recipient)
+		if normalized, err := phone.Normalize(recipient); err == nil {
+			number = normalized
+		}
+		key := l.KeyPrefix + ":phone:" + number
+		keys = append(keys, key)
+		phones[key] = recipient
+	}
+	return c.takeRateLimit(ctx, keys, l.PhoneRules, phones)
+}
+
+// takeRateLimit 扣减令牌，令牌不足时在 MaxWait 内等待，超时返回 *RateLimitError
+func (c *Client) takeRateLimit(ctx context.Context, keys []string, rules []RateLimitRule, phones map[string]string) error {
+	l := c.rateLimiter
+	deadline := time.Now().Add(l.MaxWait)
+
+	for {
+		result, err := l.Store.Take(ctx, keys, rules)
+		if err != nil {
+			return c.errorf(msgRateLimitStore, err)
+		}
+		if result.Allowed {
+			return nil
+		}
+
+		if l.MaxWait <= 0 || time.Now().Add(result.RetryAfter).After(deadline) {
+			return &RateLimitError{Phone: phones[result.Key], RetryAfter: result.RetryAfter, locale: c.locale}
+		}
+
+		timer := time.NewTimer(result.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ===== 进程内限流存储 =====
+
+// MemoryRateLimitStore 进程内令牌桶存储（并发安全）
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	ops     int
+}
+
+// tokenBucket 令牌桶
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	rule    RateLimitRule
+}
+
+// NewMemoryRateLimitStore 创建进程内限流存储
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{buckets: make(map[string]*tokenBucket)}
+}
+
+// Take 实现 RateLimitStore 接口
+func (s *MemoryRateLimitStore) Take(ctx context.Context, keys []string, rules []RateLimitRule) (*RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	// 统计每个键需要的令牌数
+	counts := make(map[string]int, len(keys))
+	var order []string
+	for _, key := range keys {
+		if counts[key] == 0 {
+			order = append(order, key)
+		}
+		counts[key]++
+	}
+
+	// 先检查所有桶，全部满足后再扣减
+	var buckets []*tokenBucket
+	for _, key := range order {
+		n := float64(counts[key])
+		for _, rule := range rules {
+			if rule.Limit <= 0 || rule.Window <= 0 {
+				continue
+			}
+			b := s.bucket(key, rule, now)
+			if b.tokens < n {
+				retryAfter := rule.Window
+				if n <= float64(rule.Limit) {
+					retryAfter = time.Duration((n - b.tokens) / b.rate())
+				}
+				return &RateLimitResult{Key: key, RetryAfter: retryAfter}, nil
+			}
+			buckets = append(buckets, b)
+		}
+	}
+
+	i := 0
+	for _, key := range order {
+		for _, rule := range rules {
+			if rule.Limit <= 0 || rule.Window <= 0 {
+				continue
+			}
+			buckets[i].tokens -= float64(counts[key])
+			i++
+		}
+	}
+
+	return &RateLimitResult{Allowed: true}, nil
+}
+
+// bucket 获取（或创建）键在指定规则下的令牌桶，并按经过的时间补充令牌
+func (s *MemoryRateLimitStore) bucket(key string, rule RateLimitRule, now time.Time) *tokenBucket {
+	id := fmt.Sprintf("%s|%d/%s", key, rule.Limit, rule.Window)
+	b, ok := s.buckets[id]
+	if !ok {
+		b = &tokenBucket{tokens: float64(rule.Limit), updated: now, rule: rule}
+		s.buckets[id] = b
+		return b
+	}
+
+	b.tokens = math.Min(float64(rule.Limit), b.tokens+float64(now.Sub(b.updated))*b.rate())
+	b.updated = now
+	return b
+}
+
+// sweep 定期清理已补满的令牌桶，避免内存无限增长
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	s.ops++
+	if s.ops%1024 != 0 {
+		return
+	}
+	for id, b := range s.buckets {
+		if now.Sub(b.updated) >= b.rule.Window {
+			delete(s.buckets, id)
+		}
+	}
+}
+
+// rate 每纳秒补充的令牌数
+func (b *tokenBucket) rate() float64 {
+	return float64(b.rule.Limit) / float64(b.rule.Window)
+}
@@ -0,0 +1,98 @@
+package submail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestMemoryRateLimitStoreIsAtomic(t *testing.T) {
+	store := submail.NewMemoryRateLimitStore()
+	rules := []submail.RateLimitRule{{Limit: 1, Window: time.Hour}}
+	ctx := context.Background()
+
+	if result, _ := store.Take(ctx, []string{"a"}, rules); !result.Allowed {
+		t.Fatal("first take rejected")
+	}
+	// b 有令牌而 a 没有：整体被拒绝，b 的令牌不被扣减
+	result, _ := store.Take(ctx, []string{"b", "a"}, rules)
+	if result.Allowed || result.Key != "a" || result.RetryAfter <= 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result, _ := store.Take(ctx, []string{"b"}, rules); !result.Allowed {
+		t.Fatal("b was charged by the rejected take")
+	}
+}
+
+func TestPhoneRateLimitNormalizesNumbers(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.RateLimiter = &submail.RateLimiter{PhoneRules: []submail.RateLimitRule{{Limit: 1, Window: time.Minute}}}
+	client := submail.NewClient(config)
+
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+
+	// 同一号码的不同写法共用一个令牌桶
+	_, err := client.SMSSend(&submail.SMSSendRequest{To: "+86 138-0013-8000", Content: "【测试】验证码1234"})
+	if !errors.Is(err, submail.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	var limitErr *submail.RateLimitError
+	if !errors.As(err, &limitErr) || limitErr.Phone != "13800138000" {
+		t.Fatalf("RateLimitError = %+v", limitErr)
+	}
+
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138001", Content: "【测试】验证码1234"}); err != nil {
+		t.Fatalf("other phone: %v", err)
+	}
+	if n := len(server.Messages()); n != 2 {
+		t.Fatalf("server received %d messages, want 2", n)
+	}
+}
+
+func TestGlobalRateLimitWaits(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.RateLimiter = &submail.RateLimiter{RequestsPerSecond: 20, Burst: 1, MaxWait: time.Second}
+	client := submail.NewClient(config)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.SMSBalance(); err != nil {
+			t.Fatalf("SMSBalance #%d: %v", i, err)
+		}
+	}
+	// 突发1次，之后每50毫秒1次
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("3 requests took %v, limiter did not wait", elapsed)
+	}
+}
+
+func TestGlobalRateLimitRejectsWithoutWait(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.RateLimiter = &submail.RateLimiter{RequestsPerSecond: 1}
+	client := submail.NewClient(config)
+
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if _, err := client.SMSBalance(); !errors.Is(err, submail.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	if n := len(server.Requests()); n != 1 {
+		t.Fatalf("server received %d requests, want 1", n)
+	}
+}
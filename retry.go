@@ -0,0 +1,145 @@
+package submail
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// RetryRequest 重试判断时的请求信息
+type RetryRequest struct {
+	Method     string // HTTP方法
+	Endpoint   string // API端点
+	Attempt    int    // 已完成的请求次数（从1开始）
+	Idempotent bool   // 请求是否幂等（发送、创建类接口为非幂等）
+	Sent       bool   // 请求是否可能已到达服务器（连接建立失败等情况为false）
+}
+
+// RetryPolicy 重试策略接口
+type RetryPolicy interface {
+	// ShouldRetry 判断请求失败后是否重试，以及重试前的等待时间
+	ShouldRetry(req *RetryRequest, err error) (delay time.Duration, retry bool)
+}
+
+// RetryPolicyFunc 函数形式的重试策略
+type RetryPolicyFunc func(req *RetryRequest, err error) (time.Duration, bool)
+
+// ShouldRetry 实现 RetryPolicy 接口
+func (f RetryPolicyFunc) ShouldRetry(req *RetryRequest, err error) (time.Duration, bool) {
+	return f(req, err)
+}
+
+// BackoffRetryPolicy 指数退避重试策略
+// 默认重试网络错误、HTTP 5xx/429 以及时间戳类API错误（151、152）。
+// 对于非幂等请求（如短信发送），只有在能确定服务器未受理时才会重试
+// （API明确返回错误、HTTP 429、连接未建立），除非设置了 RetryNonIdempotent。
+type BackoffRetryPolicy struct {
+	MaxAttempts        int           // 最大请求次数（含首次请求，默认3）
+	InitialBackoff     time.Duration // 首次重试前的等待时间（默认200毫秒）
+	MaxBackoff         time.Duration // 最大等待时间（默认5秒）
+	Multiplier         float64       // 退避倍数（默认2）
+	Jitter             float64       // 随机抖动比例，取值0-1（默认0.2）
+	RetryableCodes     []int         // 可重试的API错误码（默认151、152）
+	RetryNonIdempotent bool          // 是否允许重试可能已被受理的非幂等请求（可能导致重复发送）
+}
+
+// NewBackoffRetryPolicy 创建使用默认参数的指数退避重试策略
+func NewBackoffRetryPolicy() *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableCodes: []int{ErrTimestampError, ErrInvalidTimestamp},
+	}
+}
+
+// ShouldRetry 实现 RetryPolicy 接口
+func (p *BackoffRetryPolicy) ShouldRetry(req *RetryRequest, err error) (time.Duration, bool) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if req.Attempt >= maxAttempts {
+		return 0, false
+	}
+
+	retryable, accepted := p.classify(err)
+	if !retryable {
+		return 0, false
+	}
+
+	// 非幂等请求可能已被服务器受理，默认不重试以避免重复发送
+	if !req.Idempotent && req.Sent && accepted && !p.RetryNonIdempotent {
+		return 0, false
+	}
+
+	return p.backoff(req.Attempt), true
+}
+
+// classify 判断错误是否可重试，以及请求是否可能已被服务器受理
+func (p *BackoffRetryPolicy) classify(err error) (retryable, mayBeAccepted bool) {
+	return classifyError(err, p.RetryableCodes)
+}
+
+// backoff 计算第 attempt 次失败后的等待时间
+func (p *BackoffRetryPolicy) backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 200 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		// 在 [delay*(1-jitter), delay*(1+jitter)] 范围内随机
+		delay = delay * (1 - jitter + 2*jitter*rand.Float64())
+	}
+
+	return time.Duration(delay)
+}
+
+// isIdempotentRequest 判断请求是否幂等
+// GET、PUT、DELETE 以及查询类接口可以安全重试；其余 POST 请求（发送短信、创建模板等）视为非幂等
+func isIdempotentRequest(method, endpoint string) bool {
+	switch method {
+	case "GET", "PUT", "DELETE":
+		return true
+	}
+
+	switch endpoint {
+	case EndpointSMSReports, EndpointSMSBalance, EndpointSMSBalanceLog, EndpointSMSLog,
+		EndpointSMSMO, EndpointServiceTimestamp, EndpointServiceStatus:
+		return true
+	}
+	return false
+}
+
+// isDialError 判断是否为建立连接阶段的错误（此时请求一定未发出）
+func isDialError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package submail_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestBackoffRetryPolicyShouldRetry(t *testing.T) {
+	policy := submail.NewBackoffRetryPolicy()
+	policy.Jitter = 0
+
+	tests := []struct {
+		name  string
+		req   submail.RetryRequest
+		err   error
+		retry bool
+	}{
+		{"timestamp error", submail.RetryRequest{Attempt: 1, Sent: true}, submail.NewAPIError(submail.ErrTimestampError, ""), true},
+		{"auth error", submail.RetryRequest{Attempt: 1, Sent: true}, submail.NewAPIError(submail.ErrIncorrectAppID, ""), false},
+		{"attempts exhausted", submail.RetryRequest{Attempt: 3, Sent: true}, submail.NewAPIError(submail.ErrTimestampError, ""), false},
+		{"5xx on idempotent request", submail.RetryRequest{Attempt: 1, Idempotent: true, Sent: true}, &submail.HTTPStatusError{StatusCode: 503}, true},
+		{"5xx on send", submail.RetryRequest{Attempt: 1, Sent: true}, &submail.HTTPStatusError{StatusCode: 503}, false},
+		{"429 on send", submail.RetryRequest{Attempt: 1, Sent: true}, &submail.HTTPStatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"4xx", submail.RetryRequest{Attempt: 1, Idempotent: true, Sent: true}, &submail.HTTPStatusError{StatusCode: 404}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, retry := policy.ShouldRetry(&tt.req, tt.err); retry != tt.retry {
+				t.Fatalf("retry = %v, want %v", retry, tt.retry)
+			}
+		})
+	}
+}
+
+func TestBackoffRetryPolicyDelay(t *testing.T) {
+	policy := &submail.BackoffRetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	err := submail.NewAPIError(submail.ErrTimestampError, "")
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		delay, retry := policy.ShouldRetry(&submail.RetryRequest{Attempt: i + 1}, err)
+		if !retry || delay != w {
+			t.Fatalf("attempt %d: delay = %v (retry=%v), want %v", i+1, delay, retry, w)
+		}
+	}
+}
+
+func TestClientRetriesTimestampError(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.RetryPolicy = &submail.BackoffRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	client := submail.NewClient(config)
+
+	server.FailNext(submail.EndpointSMSSend, submail.ErrInvalidTimestamp)
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}); err != nil {
+		t.Fatalf("SMSSend: %v", err)
+	}
+	if n := countRequests(server, submail.EndpointSMSSend); n != 2 {
+		t.Fatalf("send requested %d times, want 2", n)
+	}
+	if n := len(server.Messages()); n != 1 {
+		t.Fatalf("server received %d messages, want 1", n)
+	}
+}
+
+func TestClientDoesNotRetryAcceptedSend(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	config := server.Config()
+	config.RetryPolicy = &submail.BackoffRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	client := submail.NewClient(config)
+
+	// 发送接口返回 5xx 时服务器可能已受理，不重试以免重复发送
+	server.FailNextHTTP(submail.EndpointSMSSend, http.StatusBadGateway)
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}); err == nil {
+		t.Fatal("SMSSend succeeded, want HTTP error")
+	}
+	if n := countRequests(server, submail.EndpointSMSSend); n != 1 {
+		t.Fatalf("send requested %d times, want 1", n)
+	}
+
+	// 查询接口幂等，可以重试
+	server.FailNextHTTP(submail.EndpointSMSBalance, http.StatusBadGateway)
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+	if n := countRequests(server, submail.EndpointSMSBalance); n != 2 {
+		t.Fatalf("balance requested %d times, want 2", n)
+	}
+}
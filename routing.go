@@ -0,0 +1,266 @@
+package submail
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+// 发送路由
+const (
+	RouteDomestic      = "domestic"      // 国内短信（一对多发送接口）
+	RouteInternational = "international" // 国际短信（联合发送接口）
+)
+
+// RouteTemplate 路由发送模板，同时描述国内与国际短信的内容
+type RouteTemplate struct {
+	Project      string // 国内短信模板ID，设置后国内号码使用模板一对多发送（SMSMultiXSend）
+	Signature    string // 国内模板发送的自定义短信签名（可选）
+	Content      string // 国内短信正文（支持@var(key)和@date()变量），未设置 Project 时国内号码使用一对多发送（SMSMultiSend）
+	InterContent string // 国际短信正文（支持@var(key)和@date()变量），为空时使用 Content
+	Tag          string // 自定义标签
+}
+
+// RoutingConfig 路由发送配置
+type RoutingConfig struct {
+	InterAppID          string                   // 国际短信AppID
+	InterSignature      string                   // 国际短信应用密钥
+	VerifyCodeTransform bool                     // 国际短信是否提取验证码替换@var(code)
+	Templates           map[string]RouteTemplate // 命名模板，通过 Send 按名称使用
+
+	// Locale 错误信息使用的语言，为空时使用发送客户端（*Client）的语言
+	Locale string
+}
+
+// RouteRecipient 路由发送的收件人
+type RouteRecipient struct {
+	To   string            // 手机号码（中国大陆11位号码或国际号码，支持 +86、0086 等写法）
+	Vars map[string]string // 变量
+}
+
+// RouteResult 单个收件人的路由发送结果（顺序与收件人一致）
+type RouteResult struct {
+	To     string // 原始手机号码
+	Number string // 实际发送的号码（中国大陆为11位号码，国际为 E.164 格式；号码无效时为空）
+	Route  string // 发送路由：RouteDomestic 或 RouteInternational（号码无效时为空）
+	Status string // 发送状态：success/error/skipped（skipped 表示收件人在屏蔽名单中，未发送）
+	SendID string // 发送ID
+	Fee    int    // 计费条数
+	Err    error  // 发送失败原因（号码无效时为 *ValidationError，API返回错误时为 *APIError）
+}
+
+// Success 是否发送成功
+func (r *RouteResult) Success() bool {
+	return r.Err == nil && r.Status == "success"
+}
+
+// Skipped 收件人是否因屏蔽名单被跳过
+func (r *RouteResult) Skipped() bool {
+	return r.Status == SendStatusSkipped
+}
+
+// RoutingSender 国内/国际自动路由发送
+//
+//	router := submail.NewRoutingSender(client, submail.RoutingConfig{
+//		InterAppID:     "inter_appid",
+//		InterSignature: "inter_appkey",
+//		Templates: map[string]submail.RouteTemplate{
+//			"verify": {Project: "abc123", InterContent: "[Brand] Your code is @var(code)"},
+//		},
+//	})
+//	results, err := router.Send(ctx, "verify", []submail.RouteRecipient{
+//		{To: "13800138000", Vars: map[string]string{"code": "1234"}},
+//		{To: "+85291234567", Vars: map[string]string{"code": "5678"}},
+//	})
+//
+// 中国大陆号码合并为一次一对多发送，国际号码逐个通过联合发送接口发送（正文中的变量在本地替换）
+type RoutingSender struct {
+	sender SMSSender
+	config RoutingConfig
+	locale string
+	render func(content string, vars map[string]string) string
+}
+
+// NewRoutingSender 创建路由发送器，sender 通常为 *Client，也可以是 submailtest.Recorder 等实现
+func NewRoutingSender(sender SMSSender, config RoutingConfig) *RoutingSender {
+	r := &RoutingSender{sender: sender, config: config}
+
+	client, isClient := sender.(*Client)
+	switch {
+	case config.Locale != "":
+		r.locale = normalizeLocale(config.Locale)
+	case isClient:
+		r.locale = client.Locale()
+	default:
+		r.locale = DefaultLocale()
+	}
+
+	if isClient {
+		r.render = client.ProcessVariables
+	} else {
+		vp := NewVariableProcessor()
+		vp.SetLocale(r.locale)
+		r.render = vp.ProcessVariables
+	}
+	return r
+}
+
+// Send 使用命名模板发送
+func (r *RoutingSender) Send(ctx context.Context, template string, recipients []RouteRecipient) ([]RouteResult, error) {
+	tpl, ok := r.config.Templates[template]
+	if !ok {
+		return nil, localizedError(r.locale, msgUnknownRouteTemplate, template)
+	}
+	return r.SendTemplate(ctx, tpl, recipients)
+}
+
+// SendTemplate 使用指定模板发送，按号码自动选择国内或国际路由
+// 返回的 error 仅表示模板配置错误，各收件人的发送结果（含失败原因）见 RouteResult
+func (r *RoutingSender) SendTemplate(ctx context.Context, tpl RouteTemplate, recipients []RouteRecipient) ([]RouteResult, error) {
+	if tpl.Project == "" && tpl.Content == "" {
+		return nil, &ValidationError{Field: "content", Message: localize(r.locale, msgRouteContentRequired)}
+	}
+
+	results := make([]RouteResult, len(recipients))
+	var domestic, international []int
+	for i, recipient := range recipients {
+		results[i].To = recipient.To
+		n, err := phone.Parse(recipient.To)
+		if err != nil {
+			results[i].Status = "error"
+			results[i].Err = &ValidationError{Field: "to", Message: localizef(r.locale, msgInvalidPhone, recipient.To)}
+			continue
+		}
+		results[i].Number = n.String()
+		if n.IsMainland() {
+			results[i].Route = RouteDomestic
+			domestic = append(domestic, i)
+		} else {
+			results[i].Route = RouteInternational
+			international = append(international, i)
+		}
+	}
+
+	if len(domestic) > 0 {
+		r.sendDomestic(ctx, tpl, recipients, results, domestic)
+	}
+	for _, i := range international {
+		r.sendInternational(ctx, tpl, recipients[i], &results[i])
+	}
+	return results, nil
+}
+
+// sendDomestic 国内号码合并为一次一对多发送
+func (r *RoutingSender) sendDomestic(ctx context.Context, tpl RouteTemplate, recipients []RouteRecipient, results []RouteResult, indexes []int) {
+	var (
+		resp *SMSMultiSendResponse
+		err  error
+	)
+	if tpl.Project != "" {
+		items := make([]SMSMultiXItem, 0, len(indexes))
+		for _, i := range indexes {
+			items = append(items, SMSMultiXItem{To: results[i].Number, Vars: recipients[i].Vars})
+		}
+		resp, err = r.sender.SMSMultiXSendCtx(ctx, &SMSMultiXSendRequest{
+			Multi:        items,
+			Project:      tpl.Project,
+			SMSSignature: tpl.Signature,
+			Tag:          tpl.Tag,
+		})
+	} else {
+		items := make([]SMSMultiItem, 0, len(indexes))
+		for _, i := range indexes {
+			items = append(items, SMSMultiItem{To: results[i].Number, Vars: recipients[i].Vars})
+		}
+		resp, err = r.sender.SMSMultiSendCtx(ctx, &SMSMultiSendRequest{
+			Content: tpl.Content,
+			Multi:   items,
+			Tag:     tpl.Tag,
+		})
+	}
+
+	if err != nil || resp == nil {
+		for _, i := range indexes {
+			results[i].Status = "error"
+			results[i].Err = err
+		}
+		return
+	}
+
+	// 响应与请求顺序一致；数量不一致时按手机号匹配
+	items := *resp
+	byNumber := make(map[string]SMSSendResult, len(items))
+	for _, item := range items {
+		byNumber[item.To] = item
+	}
+	for k, i := range indexes {
+		item, ok := byNumber[results[i].Number]
+		if len(items) == len(indexes) {
+			item, ok = items[k], true
+		}
+		if !ok {
+			results[i].Status = "error"
+			results[i].Err = localizedError(r.locale, msgRouteNoResult, results[i].Number)
+			continue
+		}
+		r.applyResult(&results[i], item.Status, item.SendID, item.Fee, item.Code, item.Msg)
+	}
+}
+
+// sendInternational 国际号码通过联合发送接口发送
+func (r *RoutingSender) sendInternational(ctx context.Context, tpl RouteTemplate, recipient RouteRecipient, result *RouteResult) {
+	interContent := tpl.InterContent
+	if interContent == "" {
+		interContent = tpl.Content
+	}
+	if interContent == "" {
+		result.Status = "error"
+		result.Err = &ValidationError{Field: "inter_content", Message: localize(r.locale, msgInterContentRequired)}
+		return
+	}
+	interContent = r.render(interContent, recipient.Vars)
+
+	// 联合发送接口要求国内正文，模板发送时使用国际正文代替
+	content := interContent
+	if tpl.Content != "" {
+		content = r.render(tpl.Content, recipient.Vars)
+	}
+
+	codeTransform := "false"
+	if r.config.VerifyCodeTransform {
+		codeTransform = "true"
+	}
+
+	resp, err := r.sender.SMSUnionSendCtx(ctx, &SMSUnionSendRequest{
+		To:                          result.Number,
+		Content:                     content,
+		InterAppID:                  r.config.InterAppID,
+		InterSignature:              r.config.InterSignature,
+		InterContent:                interContent,
+		IntersmsVerifyCodeTransform: codeTransform,
+		Tag:                         tpl.Tag,
+	})
+	// 收件人在屏蔽名单中时按跳过处理而非失败
+	if errors.Is(err, ErrSuppressed) {
+		result.Status = SendStatusSkipped
+		return
+	}
+	if err != nil || resp == nil {
+		result.Status = "error"
+		result.Err = err
+		return
+	}
+	r.applyResult(result, resp.Status, resp.SendID, resp.Fee, resp.Code, resp.Msg)
+}
+
+// applyResult 填充发送结果，API返回错误时转换为 *APIError，屏蔽名单跳过的结果原样保留
+func (r *RoutingSender) applyResult(result *RouteResult, status, sendID string, fee, code int, msg string) {
+	result.Status = status
+	result.SendID = sendID
+	result.Fee = fee
+	if status != "success" && status != SendStatusSkipped {
+		result.Status = "error"
+		result.Err = NewAPIErrorWithLocale(r.locale, code, msg)
+	}
+}
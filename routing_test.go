@@ -0,0 +1,114 @@
+package submail_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestRoutingSenderRoutesByNumber(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	router := submail.NewRoutingSender(client, submail.RoutingConfig{
+		InterAppID:     "inter-app",
+		InterSignature: "inter-key",
+		Templates: map[string]submail.RouteTemplate{
+			"verify": {Content: "【测试】验证码@var(code)", InterContent: "[Test] Your code is @var(code)"},
+		},
+	})
+
+	results, err := router.Send(context.Background(), "verify", []submail.RouteRecipient{
+		{To: "+86 138 0013 8000", Vars: map[string]string{"code": "1111"}},
+		{To: "+852 9123 4567", Vars: map[string]string{"code": "2222"}},
+		{To: "12345"},
+		{To: "13800138001", Vars: map[string]string{"code": "3333"}},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	want := []struct {
+		number, route string
+		success       bool
+	}{
+		{"13800138000", submail.RouteDomestic, true},
+		{"+85291234567", submail.RouteInternational, true},
+		{"", "", false},
+		{"13800138001", submail.RouteDomestic, true},
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Number != w.number || r.Route != w.route || r.Success() != w.success {
+			t.Errorf("result %d = %+v, want %+v", i, r, w)
+		}
+	}
+	if !submail.IsValidationError(results[2].Err) {
+		t.Errorf("invalid number err = %v, want *ValidationError", results[2].Err)
+	}
+
+	if n := countRequests(server, submail.EndpointSMSMultiSend); n != 1 {
+		t.Fatalf("domestic numbers sent in %d requests, want 1", n)
+	}
+	if msgs := server.MessagesTo("+85291234567"); len(msgs) != 1 || msgs[0].Content != "[Test] Your code is 2222" {
+		t.Fatalf("international message = %+v", msgs)
+	}
+	if msgs := server.MessagesTo("13800138001"); len(msgs) != 1 || msgs[0].Content != "【测试】验证码3333" {
+		t.Fatalf("domestic message = %+v", msgs)
+	}
+}
+
+func TestRoutingSenderKeepsSkippedResults(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	suppression := submail.NewSuppressionList(submail.NewMemorySuppressionStore())
+	ctx := context.Background()
+	suppression.Add(ctx, "13800138000", submail.SuppressionManual, "")
+	suppression.Add(ctx, "+85291234567", submail.SuppressionManual, "")
+
+	config := server.Config()
+	config.Suppression = suppression
+	router := submail.NewRoutingSender(submail.NewClient(config), submail.RoutingConfig{})
+
+	results, err := router.SendTemplate(ctx, submail.RouteTemplate{Content: "【测试】您好"}, []submail.RouteRecipient{
+		{To: "13800138000"},
+		{To: "13800138001"},
+		{To: "+85291234567"},
+	})
+	if err != nil {
+		t.Fatalf("SendTemplate: %v", err)
+	}
+	for _, i := range []int{0, 2} {
+		if !results[i].Skipped() || results[i].Err != nil || results[i].Success() {
+			t.Errorf("result %d = %+v, want skipped without error", i, results[i])
+		}
+	}
+	if !results[1].Success() {
+		t.Errorf("result 1 = %+v, want success", results[1])
+	}
+	if n := len(server.Messages()); n != 1 {
+		t.Fatalf("server received %d messages, want 1", n)
+	}
+}
+
+func TestRoutingSenderAPIError(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	recorder.Return("SMSUnionSend", &submail.SMSSendResponse{BaseResponse: submail.BaseResponse{Status: "error", Code: submail.ErrTemplateInvalid}}, nil)
+	router := submail.NewRoutingSender(recorder, submail.RoutingConfig{})
+
+	results, err := router.SendTemplate(context.Background(), submail.RouteTemplate{Content: "hello"}, []submail.RouteRecipient{{To: "+85291234567"}})
+	if err != nil {
+		t.Fatalf("SendTemplate: %v", err)
+	}
+	if results[0].Status != "error" || !submail.IsAPIErrorCode(results[0].Err, submail.ErrTemplateInvalid) {
+		t.Fatalf("result = %+v, want API error 118", results[0])
+	}
+
+	if _, err := router.Send(context.Background(), "missing", nil); err == nil {
+		t.Fatal("Send accepted an unknown template")
+	}
+}
@@ -0,0 +1,156 @@
+package submail
+
+import (
+	"context"
+	"time"
+)
+
+// QuietHours 免打扰时段：每天 Start 至 End 之间不发送，End 早于 Start 时跨越零点（如 21:00 至次日 08:00）
+type QuietHours struct {
+	Start time.Duration // 开始时间（距零点的时长，如 21*time.Hour）
+	End   time.Duration // 结束时间（如 8*time.Hour）
+
+	// Location 时段所在的时区（默认使用客户端 VariableProcessor 的时区，即 Client.SetTimezone 设置的时区，
+	// 每次计算发送时间时读取，创建 Scheduler 后修改时区同样生效）
+	Location *time.Location
+}
+
+// Contains 时间是否处于免打扰时段内
+func (q *QuietHours) Contains(t time.Time) bool {
+	return q.Next(t).After(t)
+}
+
+// Next 返回不早于 t 且不在免打扰时段内的最早时间（t 不在时段内时返回 t）
+func (q *QuietHours) Next(t time.Time) time.Time {
+	if q == nil || q.Start == q.End {
+		return t
+	}
+
+	loc := q.Location
+	if loc == nil {
+		loc = t.Location()
+	}
+	local := t.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	offset := local.Sub(midnight)
+
+	switch {
+	case q.Start < q.End && offset >= q.Start && offset < q.End:
+		return midnight.Add(q.End)
+	case q.Start > q.End && offset >= q.Start:
+		// 跨越零点：结束时间在次日
+		return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc).Add(q.End)
+	case q.Start > q.End && offset < q.End:
+		return midnight.Add(q.End)
+	}
+	return t
+}
+
+// SchedulerConfig 定时发送配置
+type SchedulerConfig struct {
+	// Outbox 待发送任务的存储与重试等配置（Store 必填），任务保存在发件箱中，进程重启后继续执行
+	Outbox OutboxConfig
+
+	// QuietHours 免打扰时段（可选），计划时间或重试时间落在时段内的任务延后到时段结束后发送
+	QuietHours *QuietHours
+}
+
+// Scheduler 定时发送：在指定时间或延迟后调用 SMSXSend / SMSBatchXSend，
+// 基于发件箱实现，支持免打扰时段与按任务ID取消
+//
+//	scheduler := submail.NewScheduler(client, submail.SchedulerConfig{
+//		Outbox:     submail.OutboxConfig{Store: store},
+//		QuietHours: &submail.QuietHours{Start: 21 * time.Hour, End: 8 * time.Hour},
+//	})
+//	go scheduler.Run(ctx)
+//	id, err := scheduler.ScheduleXSendAfter(ctx, req, 30*time.Minute)
+type Scheduler struct {
+	*Outbox
+	quietHours *QuietHours
+	timezone   func() *time.Location
+}
+
+// NewScheduler 创建定时发送，sender 通常为 *Client
+func NewScheduler(sender SMSSender, config SchedulerConfig) *Scheduler {
+	s := &Scheduler{Outbox: NewOutbox(sender, config.Outbox)}
+
+	if config.QuietHours != nil {
+		quiet := *config.QuietHours
+		s.quietHours = &quiet
+		if client, ok := sender.(*Client); ok {
+			s.timezone = client.Timezone
+		} else {
+			s.timezone = NewVariableProcessor().Timezone
+		}
+		s.holdUntil = s.quietNext
+	}
+	return s
+}
+
+// ScheduleXSend 在指定时间发送模板短信，返回任务ID
+// 时间已过去时立即发送；时间落在免打扰时段内时延后到时段结束，可通过 Get 查看实际发送时间（NextAttemptAt）
+func (s *Scheduler) ScheduleXSend(ctx context.Context, req *SMSXSendRequest, at time.Time) (string, error) {
+	if req == nil {
+		return "", localizedError(s.locale, msgRequestNil)
+	}
+	return s.Enqueue(ctx, &OutboxMessage{
+		Endpoint:      EndpointSMSXSend,
+		To:            req.To,
+		Project:       req.Project,
+		Vars:          req.Vars,
+		Signature:     req.SMSSignature,
+		Tag:           req.Tag,
+		NextAttemptAt: s.sendTime(at),
+	})
+}
+
+// ScheduleXSendAfter 在延迟 delay 后发送模板短信，返回任务ID
+func (s *Scheduler) ScheduleXSendAfter(ctx context.Context, req *SMSXSendRequest, delay time.Duration) (string, error) {
+	return s.ScheduleXSend(ctx, req, s.now().Add(delay))
+}
+
+// ScheduleBatchXSend 在指定时间批量群发模板短信，返回任务ID（时间规则同 ScheduleXSend）
+func (s *Scheduler) ScheduleBatchXSend(ctx context.Context, req *SMSBatchXSendRequest, at time.Time) (string, error) {
+	if req == nil {
+		return "", localizedError(s.locale, msgRequestNil)
+	}
+	return s.Enqueue(ctx, &OutboxMessage{
+		Endpoint:      EndpointSMSBatchXSend,
+		To:            req.To,
+		Project:       req.Project,
+		Vars:          req.Vars,
+		Signature:     req.SMSSignature,
+		Tag:           req.Tag,
+		NextAttemptAt: s.sendTime(at),
+	})
+}
+
+// ScheduleBatchXSendAfter 在延迟 delay 后批量群发模板短信，返回任务ID
+func (s *Scheduler) ScheduleBatchXSendAfter(ctx context.Context, req *SMSBatchXSendRequest, delay time.Duration) (string, error) {
+	return s.ScheduleBatchXSend(ctx, req, s.now().Add(delay))
+}
+
+// Pending 列出尚未发送的任务（按发送时间排序），limit 小于等于0时不限制数量
+func (s *Scheduler) Pending(ctx context.Context, limit int) ([]*OutboxMessage, error) {
+	return s.config.Store.List(ctx, OutboxPending, limit)
+}
+
+// sendTime 计划发送时间，零值表示立即发送，并避开免打扰时段
+func (s *Scheduler) sendTime(at time.Time) time.Time {
+	if at.IsZero() {
+		at = s.now()
+	}
+	return s.quietNext(at)
+}
+
+// quietNext 避开免打扰时段的最早发送时间，未指定时区时使用客户端当前的时区
+func (s *Scheduler) quietNext(t time.Time) time.Time {
+	if s.quietHours == nil {
+		return t
+	}
+	quiet := *s.quietHours
+	if quiet.Location == nil {
+		quiet.Location = s.timezone()
+	}
+	return quiet.Next(t)
+}
@@ -0,0 +1,108 @@
+package submail_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestQuietHoursNext(t *testing.T) {
+	day := func(d, h, m int) time.Time { return time.Date(2026, 1, d, h, m, 0, 0, time.UTC) }
+	night := &submail.QuietHours{Start: 21 * time.Hour, End: 8 * time.Hour, Location: time.UTC}
+	lunch := &submail.QuietHours{Start: 12 * time.Hour, End: 14 * time.Hour, Location: time.UTC}
+
+	tests := []struct {
+		name  string
+		quiet *submail.QuietHours
+		at    time.Time
+		want  time.Time
+	}{
+		{"跨零点-当晚", night, day(1, 22, 30), day(2, 8, 0)},
+		{"跨零点-凌晨", night, day(2, 3, 0), day(2, 8, 0)},
+		{"跨零点-开始时刻", night, day(1, 21, 0), day(2, 8, 0)},
+		{"跨零点-结束时刻", night, day(2, 8, 0), day(2, 8, 0)},
+		{"跨零点-白天", night, day(1, 10, 0), day(1, 10, 0)},
+		{"当日时段内", lunch, day(1, 12, 15), day(1, 14, 0)},
+		{"当日时段外", lunch, day(1, 15, 0), day(1, 15, 0)},
+		{"空时段", &submail.QuietHours{Start: time.Hour, End: time.Hour}, day(1, 1, 0), day(1, 1, 0)},
+		{"nil", nil, day(1, 22, 0), day(1, 22, 0)},
+	}
+	for _, tt := range tests {
+		got := tt.quiet.Next(tt.at)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: Next(%v) = %v, want %v", tt.name, tt.at, got, tt.want)
+		}
+		if contains := tt.quiet.Contains(tt.at); contains != !tt.want.Equal(tt.at) {
+			t.Errorf("%s: Contains(%v) = %v", tt.name, tt.at, contains)
+		}
+	}
+}
+
+func TestSchedulerReadsTimezoneLazily(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	scheduler := submail.NewScheduler(client, submail.SchedulerConfig{
+		Outbox:     submail.OutboxConfig{Store: submail.NewMemoryOutboxStore()},
+		QuietHours: &submail.QuietHours{Start: 21 * time.Hour, End: 8 * time.Hour},
+	})
+	// 创建 Scheduler 之后修改时区，免打扰时段按新时区计算
+	if err := client.SetTimezone("UTC"); err != nil {
+		t.Fatalf("SetTimezone: %v", err)
+	}
+
+	ctx := context.Background()
+	at := time.Date(2026, 1, 1, 22, 0, 0, 0, time.UTC)
+	id, err := scheduler.ScheduleXSend(ctx, &submail.SMSXSendRequest{To: "13800138000", Project: "abc123"}, at)
+	if err != nil {
+		t.Fatalf("ScheduleXSend: %v", err)
+	}
+	msg, _ := scheduler.Get(ctx, id)
+	if want := time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC); !msg.NextAttemptAt.Equal(want) {
+		t.Fatalf("NextAttemptAt = %v, want %v", msg.NextAttemptAt, want)
+	}
+}
+
+func TestSchedulerDispatchAndCancel(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	project := server.AddTemplate(submail.SMSTemplate{SMSSignature: "【测试】", SMSContent: "【测试】验证码@var(code)"})
+
+	scheduler := submail.NewScheduler(submail.NewClient(server.Config()), submail.SchedulerConfig{
+		Outbox: submail.OutboxConfig{Store: submail.NewMemoryOutboxStore()},
+	})
+
+	ctx := context.Background()
+	later, _ := scheduler.ScheduleXSendAfter(ctx, &submail.SMSXSendRequest{To: "13800138001", Project: project}, time.Hour)
+	due, _ := scheduler.ScheduleXSend(ctx, &submail.SMSXSendRequest{
+		To: "13800138000", Project: project, Vars: map[string]string{"code": "1234"},
+	}, time.Now().Add(-time.Minute))
+
+	if n, err := scheduler.DispatchOnce(ctx); n != 1 || err != nil {
+		t.Fatalf("DispatchOnce = %d, %v, want only the due task", n, err)
+	}
+	if msg, _ := scheduler.Get(ctx, due); msg.Status != submail.OutboxSent {
+		t.Fatalf("due task = %+v", msg)
+	}
+	if msgs := server.MessagesTo("13800138000"); len(msgs) != 1 || msgs[0].Content != "【测试】验证码1234" {
+		t.Fatalf("messages = %+v", msgs)
+	}
+
+	pending, _ := scheduler.Pending(ctx, 0)
+	if len(pending) != 1 || pending[0].ID != later {
+		t.Fatalf("Pending = %+v", pending)
+	}
+	if err := scheduler.Cancel(ctx, later); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if pending, _ := scheduler.Pending(ctx, 0); len(pending) != 0 {
+		t.Fatalf("Pending after Cancel = %+v", pending)
+	}
+	if err := scheduler.Cancel(ctx, due); err == nil {
+		t.Fatal("Cancel accepted a sent task")
+	}
+}
@@ -0,0 +1,176 @@
+package submail
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// 短信编码
+const (
+	EncodingGSM7 = "GSM-7" // GSM 7位默认字母表（纯英文等），单条160字符，长短信每条153字符
+	EncodingUCS2 = "UCS-2" // UCS-2（含中文等），单条70字符，长短信每条67字符
+)
+
+// 每条短信的字符数上限
+const (
+	gsm7SingleLimit = 160
+	gsm7MultiLimit  = 153
+	ucs2SingleLimit = 70
+	ucs2MultiLimit  = 67
+)
+
+// gsm7Basic GSM 03.38 默认字母表
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension GSM 03.38 扩展字符（需要转义，每个占2个字符）
+const gsm7Extension = "\f^{}\\[~]|€"
+
+// SegmentInfo 短信计费条数分析结果
+type SegmentInfo struct {
+	Encoding   string // 编码：EncodingGSM7 或 EncodingUCS2
+	Length     int    // 计费字符数（GSM-7 扩展字符计2个，UCS-2 中 emoji 等增补字符计2个）
+	Segments   int    // 计费条数（内容为空时为0）
+	PerSegment int    // 当前条数下每条的字符数上限（160/153 或 70/67）
+	Remaining  int    // 最后一条剩余可用字符数
+}
+
+// AnalyzeSegments 分析短信的编码与计费条数
+// content 为已处理变量的短信正文（如 ProcessVariables 的结果），signature 为短信签名（如 【SUBMAIL】），
+// 正文中已包含签名时不会重复计算
+func AnalyzeSegments(content, signature string) *SegmentInfo {
+	text := content
+	if signature != "" && !strings.Contains(content, signature) {
+		text = signature + content
+	}
+
+	if isGSM7(text) {
+		return packSegments(EncodingGSM7, gsm7Units(text), gsm7SingleLimit, gsm7MultiLimit)
+	}
+	return packSegments(EncodingUCS2, ucs2Units(text), ucs2SingleLimit, ucs2MultiLimit)
+}
+
+// SegmentCount 计算短信计费条数，参数同 AnalyzeSegments
+func SegmentCount(content, signature string) int {
+	return AnalyzeSegments(content, signature).Segments
+}
+
+// isGSM7 判断文本是否可以全部使用 GSM-7 编码
+func isGSM7(text string) bool {
+	for _, r := range text {
+		if !strings.ContainsRune(gsm7Basic, r) && !strings.ContainsRune(gsm7Extension, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// gsm7Units 每个字符占用的 GSM-7 字符数
+func gsm7Units(text string) []int {
+	units := make([]int, 0, len(text))
+	for _, r := range text {
+		if strings.ContainsRune(gsm7Extension, r) {
+			units = append(units, 2)
+		} else {
+			units = append(units, 1)
+		}
+	}
+	return units
+}
+
+// ucs2Units 每个字符占用的 UTF-16 码元数
+func ucs2Units(text string) []int {
+	units := make([]int, 0, len(text))
+	for _, r := range text {
+		units = append(units, utf16.RuneLen(r))
+	}
+	return units
+}
+
+// packSegments 按长短信规则拆分：不超过单条上限时计1条，否则按每条上限依次拆分，转义字符与代理对不会被拆开
+func packSegments(encoding string, units []int, singleLimit, multiLimit int) *SegmentInfo {
+	info := &SegmentInfo{Encoding: encoding, PerSegment: singleLimit}
+	for _, n := range units {
+		info.Length += n
+	}
+
+	switch {
+	case info.Length == 0:
+		info.Remaining = singleLimit
+		return info
+	case info.Length <= singleLimit:
+		info.Segments = 1
+		info.Remaining = singleLimit - info.Length
+		return info
+	}
+
+	info.PerSegment = multiLimit
+	info.Segments = 1
+	used := 0
+	for _, n := range units {
+		if used+n > multiLimit {
+			info.Segments++
+			used = 0
+		}
+		used += n
+	}
+	info.Remaining = multiLimit - used
+	return info
+}
+
+// ===== 批量计费预估 =====
+
+// FeeEstimate 批量发送的计费预估
+type FeeEstimate struct {
+	Recipients int   // 收件人数
+	Segments   int   // 预估总计费条数
+	Items      []int // 每个收件人的计费条数（顺序与收件人一致）
+}
+
+// WithinBalance 判断余额是否足够（balance 为 SMSBalanceResponse 中的 Balance 或 TransactionalBalance）
+func (e *FeeEstimate) WithinBalance(balance string) (bool, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(balance), 64)
+	if err != nil {
+		return false, err
+	}
+	return float64(e.Segments) <= value, nil
+}
+
+// add 累加一个收件人的计费条数
+func (e *FeeEstimate) add(segments int) {
+	e.Recipients++
+	e.Segments += segments
+	e.Items = append(e.Items, segments)
+}
+
+// EstimateMultiSend 预估一对多发送的计费条数
+// content 为短信正文（变量使用客户端的变量处理器替换），signature 为正文中未包含签名时使用的短信签名
+func (c *Client) EstimateMultiSend(content, signature string, recipients []SMSMultiItem) *FeeEstimate {
+	if c.marketing != nil {
+		// 与发送时一致，营销类短信计入自动追加的退订提示
+		if prepared, _ := c.PrepareContent(content); prepared.FooterAdded {
+			content = prepared.Content
+		}
+	}
+
+	estimate := &FeeEstimate{Items: make([]int, 0, len(recipients))}
+	for _, item := range recipients {
+		estimate.add(SegmentCount(c.ProcessVariables(content, item.Vars), signature))
+	}
+	return estimate
+}
+
+// EstimateMultiXSend 预估模板一对多发送的计费条数
+// content 与 signature 为模板的正文与签名（可通过 SMSTemplateGet 获取 SMSContent、SMSSignature），
+// 收件人单独设置了 SMSSignature 时使用收件人的签名
+func (c *Client) EstimateMultiXSend(content, signature string, recipients []SMSMultiXItem) *FeeEstimate {
+	estimate := &FeeEstimate{Items: make([]int, 0, len(recipients))}
+	for _, item := range recipients {
+		sig := signature
+		if item.SMSSignature != "" {
+			sig = item.SMSSignature
+		}
+		estimate.add(SegmentCount(c.ProcessVariables(content, item.Vars), sig))
+	}
+	return estimate
+}
@@ -0,0 +1,75 @@
+package submail_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+)
+
+func TestAnalyzeSegments(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		signature string
+		encoding  string
+		length    int
+		segments  int
+		remaining int
+	}{
+		{"empty", "", "", submail.EncodingGSM7, 0, 0, 160},
+		{"gsm7 single", strings.Repeat("a", 160), "", submail.EncodingGSM7, 160, 1, 0},
+		{"gsm7 multi", strings.Repeat("a", 161), "", submail.EncodingGSM7, 161, 2, 145},
+		{"gsm7 extension counts twice", strings.Repeat("€", 80), "", submail.EncodingGSM7, 160, 1, 0},
+		{"ucs2 single", strings.Repeat("中", 70), "", submail.EncodingUCS2, 70, 1, 0},
+		{"ucs2 multi", strings.Repeat("中", 71), "", submail.EncodingUCS2, 71, 2, 63},
+		{"signature prepended", strings.Repeat("中", 66), "【测试】", submail.EncodingUCS2, 70, 1, 0},
+		{"signature already included", "【测试】" + strings.Repeat("中", 66), "【测试】", submail.EncodingUCS2, 70, 1, 0},
+		{"emoji is a surrogate pair", "😀", "", submail.EncodingUCS2, 2, 1, 68},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := submail.AnalyzeSegments(tt.content, tt.signature)
+			if info.Encoding != tt.encoding || info.Length != tt.length || info.Segments != tt.segments || info.Remaining != tt.remaining {
+				t.Fatalf("AnalyzeSegments = %+v", info)
+			}
+		})
+	}
+}
+
+func TestSegmentsDoNotSplitSurrogatePairs(t *testing.T) {
+	// 66个汉字后的 emoji 无法放入第一条的剩余1个字符，整体移到第二条
+	content := strings.Repeat("中", 66) + "😀" + strings.Repeat("中", 10)
+	info := submail.AnalyzeSegments(content, "")
+	if info.Segments != 2 || info.Remaining != 67-12 {
+		t.Fatalf("AnalyzeSegments = %+v", info)
+	}
+}
+
+func TestEstimateMultiSend(t *testing.T) {
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key"})
+	estimate := client.EstimateMultiSend("【测试】您好@var(name)", "", []submail.SMSMultiItem{
+		{To: "13800138000", Vars: map[string]string{"name": "张三"}},
+		{To: "13800138001", Vars: map[string]string{"name": strings.Repeat("长", 70)}},
+	})
+	if estimate.Recipients != 2 || estimate.Segments != 3 || estimate.Items[0] != 1 || estimate.Items[1] != 2 {
+		t.Fatalf("estimate = %+v", estimate)
+	}
+
+	if ok, err := estimate.WithinBalance("3"); err != nil || !ok {
+		t.Fatalf("WithinBalance(3) = %v, %v", ok, err)
+	}
+	if ok, _ := estimate.WithinBalance("2.5"); ok {
+		t.Fatal("WithinBalance(2.5) = true")
+	}
+	if _, err := estimate.WithinBalance("n/a"); err == nil {
+		t.Fatal("WithinBalance accepted an invalid balance")
+	}
+
+	x := client.EstimateMultiXSend("【测试】验证码@var(code)", "【测试】", []submail.SMSMultiXItem{
+		{To: "13800138000", Vars: map[string]string{"code": "1234"}, SMSSignature: "【另一个签名】"},
+	})
+	if x.Segments != 1 {
+		t.Fatalf("EstimateMultiXSend = %+v", x)
+	}
+}
@@ -2,19 +2,28 @@ package submail
 
 import (
 	"bytes"
+	"context"
 	"crypto/md5"
 	"crypto/sha1"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime/multipart"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/zhoudm1743/submail/phone"
 )
 
 // 常量定义
@@ -56,13 +65,28 @@ const (
 type Client struct {
 	AppID          string             // App ID (应用ID)
 	AppKey         string             // App Key (应用密钥，用于签名计算)
-	BaseURL        string             // API基础URL
+	BaseURL        string             // API基础URL（配置多个地址时为首选地址）
 	client         *http.Client       // HTTP客户端
 	format         string             // 响应格式 (json/xml)
 	useDigitalSign bool               // 是否使用数字签名模式，false为明文模式
 	signType       string             // 签名类型：md5 或 sha1，仅数字签名模式使用
 	timeout        time.Duration      // 请求超时时间
 	varProcessor   *VariableProcessor // 变量处理器
+	clock          *serverClock       // 服务器时钟（缓存时间偏移，用于数字签名）
+	retryPolicy    RetryPolicy        // 重试策略，为nil时不重试
+	decoder        ResponseDecoder    // 响应解码器（与 format 对应）
+	interceptors   []Interceptor      // 请求拦截器
+	locale         string             // 错误信息及描述使用的语言
+	rateLimiter    *RateLimiter       // 客户端限流，为nil时不限流
+	apiPool        *endpointPool      // 主API地址池
+	logPool        *endpointPool      // 日志API地址池
+	dryRun         bool               // 演练模式，发送接口不发出请求
+	dryRunSink     DryRunSink         // 演练消息输出
+	validatePhone  bool               // 发送前是否校验手机号码
+	forbiddenWords ForbiddenWords     // 内容检查使用的禁用词词典
+	suppression    *SuppressionList   // 屏蔽名单，为nil时不过滤
+	marketing      *MarketingPolicy   // 营销短信规则，为nil时不处理内容
+	logger         *log.Logger        // 警告日志
 }
 
 // Config 客户端配置
@@ -74,12 +98,75 @@ type Config struct {
 	UseDigitalSign bool          // 是否使用数字签名模式 (可选，默认false)
 	SignType       string        // 签名类型 (可选，默认md5)
 	Timeout        time.Duration // 请求超时时间 (可选，默认30秒)
+
+	// 服务器时间偏移刷新间隔 (可选，默认10分钟，仅数字签名模式使用)
+	// 设置为负数时关闭缓存，每次请求前都从服务器获取时间戳
+	TimestampSyncInterval time.Duration
+
+	// 重试策略 (可选，默认不重试)，可使用 NewBackoffRetryPolicy 创建指数退避策略
+	RetryPolicy RetryPolicy
+
+	// HTTP传输配置 (均为可选)
+	HTTPClient          *http.Client      // 自定义HTTP客户端，设置后忽略 Timeout 及以下传输选项
+	Transport           http.RoundTripper // 自定义传输层（如测试用传输层），设置后忽略以下传输选项
+	ProxyURL            *url.URL          // 代理地址
+	TLSConfig           *tls.Config       // TLS配置（如自定义根证书）
+	MaxIdleConns        int               // 最大空闲连接数
+	MaxIdleConnsPerHost int               // 每个主机的最大空闲连接数
+	MaxConnsPerHost     int               // 每个主机的最大连接数
+	IdleConnTimeout     time.Duration     // 空闲连接超时时间
+
+	// 请求拦截器 (可选)，按顺序由外向内执行，可用于日志、监控、注入请求头等
+	Interceptors []Interceptor
+
+	// 语言 (可选，默认使用 DefaultLocale，即 zh-CN)，用于API错误描述、SDK错误信息及状态描述，支持 LocaleZhCN、LocaleEN
+	Locale string
+
+	// 客户端限流 (可选)，在请求发出前按全局每秒请求数及单个手机号频率限流
+	RateLimiter *RateLimiter
+
+	// 多地址故障转移 (均为可选)
+	BaseURLs       []string              // 主API地址列表（按优先级排列，设置后忽略 BaseURL）
+	LogBaseURLs    []string              // 日志API地址列表（默认为 LogBaseURL）
+	CircuitBreaker *CircuitBreakerConfig // 熔断配置（配置了多个地址时默认启用）
+
+	// 演练模式 (可选)：发送接口照常执行参数校验、变量处理和签名，但不发出请求，
+	// 返回合成的 send_id 与预估计费，并将每条短信写入 DryRunSink（默认输出到标准库日志）
+	DryRun     bool
+	DryRunSink DryRunSink
+
+	// 关闭发送前的手机号码校验 (可选，默认校验)
+	// 默认情况下发送类接口使用 phone 包校验收件人，无效号码在请求发出前返回 *ValidationError；
+	// 号段表未收录的中国大陆手机号不会被拒绝，而是通过 Logger 记录警告后发送
+	SkipPhoneValidation bool
+
+	// 警告日志 (可选，默认使用标准库默认logger)，记录未收录的号段、屏蔽名单存储错误等不影响请求结果的问题
+	Logger *log.Logger
+
+	// 禁用词词典 (可选)，供 LintContent、LintTemplate 检查内容，可使用 WordList 或自定义实现
+	ForbiddenWords ForbiddenWords
+
+	// 屏蔽名单 (可选)：发送接口在请求前过滤名单中的收件人（一对多及批量发送中返回 status 为 skipped 的结果），
+	// 并自动加入返回 114（黑名单）、253（已退订）错误的号码
+	Suppression *SuppressionList
+
+	// 营销短信规则 (可选)：SMSSend、SMSMultiSend、SMSBatchSend 发送前对内容分类，
+	// 营销类短信缺少退订提示时自动追加 OptOutFooter（默认"回T退订"），并按追加后的内容校验长度与计费条数
+	Marketing *MarketingPolicy
 }
 
 // NewClient 创建新的赛邮云客户端
 func NewClient(config Config) *Client {
-	if config.BaseURL == "" {
-		config.BaseURL = DefaultBaseURL
+	if len(config.BaseURLs) > 0 {
+		config.BaseURL = config.BaseURLs[0]
+	} else {
+		if config.BaseURL == "" {
+			config.BaseURL = DefaultBaseURL
+		}
+		config.BaseURLs = []string{config.BaseURL}
+	}
+	if len(config.LogBaseURLs) == 0 {
+		config.LogBaseURLs = []string{LogBaseURL}
 	}
 	if config.Format == "" {
 		config.Format = FormatJSON
@@ -91,16 +178,44 @@ func NewClient(config Config) *Client {
 		config.Timeout = 30 * time.Second
 	}
 
+	if config.Logger == nil {
+		config.Logger = log.Default()
+	}
+	if config.DryRun && config.DryRunSink == nil {
+		config.DryRunSink = NewLogDryRunSink(nil)
+	}
+
+	locale := normalizeLocale(config.Locale)
+	httpClient := newHTTPClient(config)
+
+	varProcessor := NewVariableProcessor()
+	varProcessor.SetLocale(locale)
+
 	return &Client{
 		AppID:          config.AppID,
 		AppKey:         config.AppKey,
 		BaseURL:        config.BaseURL,
-		client:         &http.Client{Timeout: config.Timeout},
+		client:         httpClient,
 		format:         config.Format,
 		useDigitalSign: config.UseDigitalSign,
 		signType:       config.SignType,
-		timeout:        config.Timeout,
-		varProcessor:   NewVariableProcessor(),
+		timeout:        httpClient.Timeout,
+		varProcessor:   varProcessor,
+		clock:          newServerClock(config.TimestampSyncInterval),
+		retryPolicy:    config.RetryPolicy,
+		decoder:        newResponseDecoder(config.Format, locale),
+		interceptors:   append([]Interceptor(nil), config.Interceptors...),
+		locale:         locale,
+		rateLimiter:    newRateLimiter(config.RateLimiter, config.AppID),
+		apiPool:        newEndpointPool(EndpointFamilyAPI, config.BaseURLs, config.CircuitBreaker),
+		logPool:        newEndpointPool(EndpointFamilyLog, config.LogBaseURLs, config.CircuitBreaker),
+		dryRun:         config.DryRun,
+		dryRunSink:     config.DryRunSink,
+		validatePhone:  !config.SkipPhoneValidation,
+		forbiddenWords: config.ForbiddenWords,
+		suppression:    config.Suppression,
+		marketing:      config.Marketing,
+		logger:         config.Logger,
 	}
 }
 
@@ -111,6 +226,11 @@ func (c *Client) SetTimezone(timezone string) error {
 	return c.varProcessor.SetTimezone(timezone)
 }
 
+// Timezone 获取时区（日期变量与定时发送的免打扰时段使用此时区）
+func (c *Client) Timezone() *time.Location {
+	return c.varProcessor.Timezone()
+}
+
 // ProcessVariables 处理短信内容中的变量
 func (c *Client) ProcessVariables(content string, vars map[string]string) string {
 	return c.varProcessor.ProcessVariables(content, vars)
@@ -133,9 +253,14 @@ func (c *Client) GetDateVariableDescription() map[string]string {
 
 // SMSSendWithVariables 发送带变量的短信（自动处理变量）
 func (c *Client) SMSSendWithVariables(to, content string, vars map[string]string, tag string) (*SMSSendResponse, error) {
+	return c.SMSSendWithVariablesCtx(context.Background(), to, content, vars, tag)
+}
+
+// SMSSendWithVariablesCtx 发送带变量的短信（自动处理变量，支持 context 取消与超时控制）
+func (c *Client) SMSSendWithVariablesCtx(ctx context.Context, to, content string, vars map[string]string, tag string) (*SMSSendResponse, error) {
 	// 验证变量格式
 	if errors := c.ValidateVariables(content); len(errors) > 0 {
-		return nil, fmt.Errorf("变量格式错误: %v", errors)
+		return nil, c.validationError("vars", msgInvalidVariables, errors)
 	}
 
 	// 处理变量
@@ -148,11 +273,16 @@ func (c *Client) SMSSendWithVariables(to, content string, vars map[string]string
 		Tag:     tag,
 	}
 
-	return c.SMSSend(req)
+	return c.SMSSendCtx(ctx, req)
 }
 
 // SMSXSendWithSignature 使用自定义签名发送模板短信
 func (c *Client) SMSXSendWithSignature(to, project, signature string, vars map[string]string, tag string) (*SMSSendResponse, error) {
+	return c.SMSXSendWithSignatureCtx(context.Background(), to, project, signature, vars, tag)
+}
+
+// SMSXSendWithSignatureCtx 使用自定义签名发送模板短信（支持 context 取消与超时控制）
+func (c *Client) SMSXSendWithSignatureCtx(ctx context.Context, to, project, signature string, vars map[string]string, tag string) (*SMSSendResponse, error) {
 	req := &SMSXSendRequest{
 		To:           to,
 		Project:      project,
@@ -161,14 +291,19 @@ func (c *Client) SMSXSendWithSignature(to, project, signature string, vars map[s
 		Tag:          tag,
 	}
 
-	return c.SMSXSend(req)
+	return c.SMSXSendCtx(ctx, req)
 }
 
 // SMSMultiSendWithVariables 使用变量发送一对多短信
 func (c *Client) SMSMultiSendWithVariables(content string, recipients []SMSMultiItem, tag string) (*SMSMultiSendResponse, error) {
+	return c.SMSMultiSendWithVariablesCtx(context.Background(), content, recipients, tag)
+}
+
+// SMSMultiSendWithVariablesCtx 使用变量发送一对多短信（支持 context 取消与超时控制）
+func (c *Client) SMSMultiSendWithVariablesCtx(ctx context.Context, content string, recipients []SMSMultiItem, tag string) (*SMSMultiSendResponse, error) {
 	// 验证变量格式
 	if errors := c.ValidateVariables(content); len(errors) > 0 {
-		return nil, fmt.Errorf("变量格式错误: %v", errors)
+		return nil, c.validationError("vars", msgInvalidVariables, errors)
 	}
 
 	req := &SMSMultiSendRequest{
@@ -177,11 +312,16 @@ func (c *Client) SMSMultiSendWithVariables(content string, recipients []SMSMulti
 		Tag:     tag,
 	}
 
-	return c.SMSMultiSend(req)
+	return c.SMSMultiSendCtx(ctx, req)
 }
 
 // SMSMultiXSendWithSignature 使用自定义签名发送模板一对多短信
 func (c *Client) SMSMultiXSendWithSignature(project, signature string, recipients []SMSMultiXItem, tag string) (*SMSMultiSendResponse, error) {
+	return c.SMSMultiXSendWithSignatureCtx(context.Background(), project, signature, recipients, tag)
+}
+
+// SMSMultiXSendWithSignatureCtx 使用自定义签名发送模板一对多短信（支持 context 取消与超时控制）
+func (c *Client) SMSMultiXSendWithSignatureCtx(ctx context.Context, project, signature string, recipients []SMSMultiXItem, tag string) (*SMSMultiSendResponse, error) {
 	req := &SMSMultiXSendRequest{
 		Project:      project,
 		Multi:        recipients,
@@ -189,7 +329,7 @@ func (c *Client) SMSMultiXSendWithSignature(project, signature string, recipient
 		Tag:          tag,
 	}
 
-	return c.SMSMultiXSend(req)
+	return c.SMSMultiXSendCtx(ctx, req)
 }
 
 // ===== 多条发送结果处理方法 =====
@@ -279,9 +419,14 @@ func (resp *SMSBatchSendResponse) GetStatistics() (success, failed int, totalFee
 
 // SMSBatchSendWithPhones 批量发送短信（便捷方法）
 func (c *Client) SMSBatchSendWithPhones(content string, phones []string, tag string) (*SMSBatchSendResponse, error) {
+	return c.SMSBatchSendWithPhonesCtx(context.Background(), content, phones, tag)
+}
+
+// SMSBatchSendWithPhonesCtx 批量发送短信（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSBatchSendWithPhonesCtx(ctx context.Context, content string, phones []string, tag string) (*SMSBatchSendResponse, error) {
 	// 验证变量格式
 	if errors := c.ValidateVariables(content); len(errors) > 0 {
-		return nil, fmt.Errorf("变量格式错误: %v", errors)
+		return nil, c.validationError("vars", msgInvalidVariables, errors)
 	}
 
 	// 将手机号码数组转换为逗号分隔的字符串
@@ -299,11 +444,16 @@ func (c *Client) SMSBatchSendWithPhones(content string, phones []string, tag str
 		Tag:     tag,
 	}
 
-	return c.SMSBatchSend(req)
+	return c.SMSBatchSendCtx(ctx, req)
 }
 
 // SMSBatchXSendWithPhones 批量模板发送短信（便捷方法）
 func (c *Client) SMSBatchXSendWithPhones(project string, phones []string, vars map[string]string, signature, tag string) (*SMSBatchSendResponse, error) {
+	return c.SMSBatchXSendWithPhonesCtx(context.Background(), project, phones, vars, signature, tag)
+}
+
+// SMSBatchXSendWithPhonesCtx 批量模板发送短信（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSBatchXSendWithPhonesCtx(ctx context.Context, project string, phones []string, vars map[string]string, signature, tag string) (*SMSBatchSendResponse, error) {
 	// 将手机号码数组转换为逗号分隔的字符串
 	phoneStr := ""
 	for i, phone := range phones {
@@ -321,61 +471,61 @@ func (c *Client) SMSBatchXSendWithPhones(project string, phones []string, vars m
 		Tag:          tag,
 	}
 
-	return c.SMSBatchXSend(req)
+	return c.SMSBatchXSendCtx(ctx, req)
 }
 
 // getTimestampFromServer 从服务器获取时间戳（内部使用，避免循环依赖）
-func (c *Client) getTimestampFromServer() (int64, error) {
+func (c *Client) getTimestampFromServer(ctx context.Context) (int64, error) {
 	// Service/Timestamp API 不需要授权参数
 	params := make(map[string]string)
 
-	body, err := c.doRequest("GET", EndpointServiceTimestamp, params)
+	body, err := c.doRequest(ctx, "GET", EndpointServiceTimestamp, params)
 	if err != nil {
-		return 0, fmt.Errorf("请求时间戳API失败: %v", err)
+		return 0, c.errorf(msgTimestampRequest, err)
 	}
 
 	// 检查响应是否为空
 	if len(body) == 0 {
-		return 0, fmt.Errorf("时间戳API返回空响应")
+		return 0, c.decodeError(targetTimestamp, body, c.errorf(msgEmptyResponse))
 	}
 
 	// 先尝试解析可能的错误响应
 	var errorResp struct {
-		Status string `json:"status"`
-		Code   int    `json:"code"`
-		Msg    string `json:"msg"`
+		Status string `json:"status" xml:"status"`
+		Code   int    `json:"code" xml:"code"`
+		Msg    string `json:"msg" xml:"msg"`
 	}
-	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Status == "error" {
-		return 0, fmt.Errorf("时间戳API返回错误: code=%d, msg=%s", errorResp.Code, errorResp.Msg)
+	if err := c.decoder.Decode(body, &errorResp); err == nil && errorResp.Status == "error" {
+		return 0, c.errorf(msgTimestampAPIError, NewAPIErrorWithLocale(c.locale, errorResp.Code, errorResp.Msg))
 	}
 
-	// 解析正常的时间戳响应，格式为 {"timestamp": 1414253462}
+	// 解析正常的时间戳响应，JSON格式为 {"timestamp": 1414253462}，XML格式为 <root><timestamp>1414253462</timestamp></root>
 	var timestampResp struct {
-		Timestamp int64 `json:"timestamp"`
+		Timestamp int64 `json:"timestamp" xml:"timestamp"`
 	}
-	if err := json.Unmarshal(body, &timestampResp); err != nil {
-		return 0, fmt.Errorf("解析时间戳响应失败: %v, 响应内容: %s", err, string(body))
+	if err := c.decoder.Decode(body, &timestampResp); err != nil {
+		return 0, c.decodeError(targetTimestamp, body, err)
 	}
 
 	// 检查时间戳是否有效
 	if timestampResp.Timestamp == 0 {
-		return 0, fmt.Errorf("获取到无效的时间戳: %d, 响应内容: %s", timestampResp.Timestamp, string(body))
+		return 0, c.decodeError(targetTimestamp, body, c.errorf(msgInvalidTimestamp, timestampResp.Timestamp))
 	}
 
 	return timestampResp.Timestamp, nil
 }
 
 // buildSignature 构建签名
-func (c *Client) buildSignature(params map[string]string) (string, error) {
+func (c *Client) buildSignature(ctx context.Context, params map[string]string) (string, error) {
 	if !c.useDigitalSign {
 		// 明文模式直接返回AppKey
 		return c.AppKey, nil
 	}
 
-	// 数字签名模式
-	timestamp, err := c.getTimestampFromServer()
+	// 数字签名模式，时间戳由缓存的服务器时间偏移在本地计算
+	timestamp, err := c.signTimestamp(ctx)
 	if err != nil {
-		return "", fmt.Errorf("获取时间戳失败: %v", err)
+		return "", c.errorf(msgGetTimestamp, err)
 	}
 	params["timestamp"] = strconv.FormatInt(timestamp, 10)
 
@@ -409,14 +559,14 @@ func (c *Client) buildSignature(params map[string]string) (string, error) {
 		hash := sha1.Sum([]byte(finalStr))
 		signature = fmt.Sprintf("%x", hash)
 	default:
-		return "", fmt.Errorf("不支持的签名类型: %s", c.signType)
+		return "", c.errorf(msgUnsupportedSignType, c.signType)
 	}
 
 	return signature, nil
 }
 
 // buildAuthParams 构建认证参数
-func (c *Client) buildAuthParams(params map[string]string) error {
+func (c *Client) buildAuthParams(ctx context.Context, params map[string]string) error {
 	if params == nil {
 		params = make(map[string]string)
 	}
@@ -428,7 +578,7 @@ func (c *Client) buildAuthParams(params map[string]string) error {
 		// 数字签名模式
 		params["sign_type"] = c.signType
 
-		signature, err := c.buildSignature(params)
+		signature, err := c.buildSignature(ctx, params)
 		if err != nil {
 			return err
 		}
@@ -441,125 +591,291 @@ func (c *Client) buildAuthParams(params map[string]string) error {
 	return nil
 }
 
+// handleAPIError 根据API错误调整客户端内部状态
+func (c *Client) handleAPIError(err error) {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return
+	}
+
+	// 时间戳错误说明本地缓存的服务器时间偏移已不准确，下次签名时重新同步
+	if apiErr.Code == ErrTimestampError || apiErr.Code == ErrInvalidTimestamp {
+		c.clock.invalidate()
+	}
+}
+
 // doRequest 执行HTTP请求
-func (c *Client) doRequest(method, endpoint string, params map[string]string) ([]byte, error) {
-	return c.doRequestWithBaseURL(method, endpoint, params, c.BaseURL)
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, params map[string]string) ([]byte, error) {
+	return c.doRequestWithPool(ctx, method, endpoint, params, c.apiPool)
 }
 
-// doRequestWithBaseURL 使用指定基础URL执行HTTP请求
-func (c *Client) doRequestWithBaseURL(method, endpoint string, params map[string]string, baseURL string) ([]byte, error) {
-	// 如果不是获取时间戳的请求，则构建认证参数
-	if endpoint != EndpointServiceTimestamp {
-		if err := c.buildAuthParams(params); err != nil {
-			return nil, fmt.Errorf("构建认证参数失败: %v", err)
-		}
+// doRequestWithPool 使用指定地址池执行HTTP请求
+func (c *Client) doRequestWithPool(ctx context.Context, method, endpoint string, params map[string]string, pool *endpointPool) ([]byte, error) {
+	if method != "GET" && method != "POST" && method != "DELETE" && method != "PUT" {
+		return nil, c.errorf(msgUnsupportedMethod, method)
 	}
 
-	// 构建URL
-	requestURL := baseURL + endpoint
-	if c.format == FormatXML {
-		requestURL += ".xml"
-	} else {
-		// 默认JSON格式，添加.json后缀
-		requestURL += ".json"
-	}
+	return c.execute(ctx, c.newFormRequestSpec(method, endpoint, params, pool))
+}
 
-	var req *http.Request
-	var err error
+// newFormRequestSpec 创建表单（或GET查询参数）请求
+func (c *Client) newFormRequestSpec(method, endpoint string, params map[string]string, pool *endpointPool) *requestSpec {
+	spec := &requestSpec{
+		method:   method,
+		endpoint: endpoint,
+		pool:     pool,
+		params:   params,
+		// 如果不是获取时间戳的请求，则构建认证参数
+		auth: endpoint != EndpointServiceTimestamp,
+	}
 
-	if method == "GET" {
-		// GET请求，参数放在URL中
+	spec.newRequest = func(ctx context.Context, requestURL string) (*http.Request, error) {
 		values := url.Values{}
 		for k, v := range params {
 			values.Set(k, v)
 		}
-		if len(values) > 0 {
-			requestURL += "?" + values.Encode()
+
+		if method == "GET" {
+			// GET请求，参数放在URL中
+			if len(values) > 0 {
+				requestURL += "?" + values.Encode()
+			}
+			return http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 		}
-		req, err = http.NewRequest("GET", requestURL, nil)
-	} else if method == "POST" {
-		// POST请求，参数放在body中
-		values := url.Values{}
-		for k, v := range params {
-			values.Set(k, v)
+
+		// POST/PUT/DELETE请求，参数放在body中
+		// 根据SUBMAIL文档，DELETE请求参数同样放在请求体中（类似POST）
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, strings.NewReader(values.Encode()))
+		if err != nil {
+			return nil, err
 		}
-		req, err = http.NewRequest("POST", requestURL, strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	}
+
+	return spec
+}
+
+// requestSpec 待执行的请求
+type requestSpec struct {
+	method    string            // HTTP方法
+	endpoint  string            // API端点
+	pool      *endpointPool     // 基础URL地址池
+	target    *endpoint         // 指定地址（健康探测使用，忽略熔断状态）
+	params    map[string]string // 请求参数（每次尝试前补充认证参数）
+	auth      bool              // 是否需要认证参数
+	multipart bool              // 是否为 multipart/form-data 请求
+
+	// healthCheck 响应校验（健康探测使用），返回错误时计为地址故障
+	healthCheck func(body []byte) error
+
+	// newRequest 根据当前参数构建HTTP请求，每次尝试都会调用（保证重试时时间戳和签名有效）
+	newRequest func(ctx context.Context, requestURL string) (*http.Request, error)
+}
+
+// buildRequestURL 根据响应格式构建请求URL
+func (c *Client) buildRequestURL(baseURL, endpoint string) string {
+	requestURL := baseURL + endpoint
+	if c.format == FormatXML {
+		return requestURL + ".xml"
+	}
+	// 默认JSON格式，添加.json后缀
+	return requestURL + ".json"
+}
+
+// execute 按重试策略执行请求
+func (c *Client) execute(ctx context.Context, spec *requestSpec) ([]byte, error) {
+	retryReq := &RetryRequest{
+		Method:     spec.method,
+		Endpoint:   spec.endpoint,
+		Idempotent: isIdempotentRequest(spec.method, spec.endpoint),
+	}
+
+	tried := make(map[*endpoint]bool)
+	failovers := 0
+
+	for attempt := 1; ; attempt++ {
+		ep, probe := spec.target, false
+		if ep == nil {
+			if ep, probe = spec.pool.acquire(tried); ep == nil {
+				return nil, &circuitOpenError{locale: c.locale, family: spec.pool.family}
+			}
+		}
+		tried[ep] = true
+
+		body, sent, err := c.executeOnce(ctx, spec, ep, probe, attempt)
 		if err == nil {
-			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return body, nil
 		}
-	} else if method == "DELETE" {
-		// DELETE请求，根据SUBMAIL文档，参数放在请求体中（类似POST）
-		values := url.Values{}
-		for k, v := range params {
-			values.Set(k, v)
+
+		// 调用方已取消或超时，不再重试
+		if ctx.Err() != nil {
+			return nil, err
 		}
-		req, err = http.NewRequest("DELETE", requestURL, strings.NewReader(values.Encode()))
-		if err == nil {
-			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		// 地址故障时立即切换到其他可用地址（不计入重试次数），非幂等请求仅在确定未发出时切换
+		if spec.target == nil && isEndpointFailure(err) && (!sent || retryReq.Idempotent) && spec.pool.hasAlternative(tried) {
+			failovers++
+			continue
 		}
-	} else if method == "PUT" {
-		// PUT请求，参数放在body中
-		values := url.Values{}
-		for k, v := range params {
-			values.Set(k, v)
+
+		if c.retryPolicy == nil {
+			return nil, err
 		}
-		req, err = http.NewRequest("PUT", requestURL, strings.NewReader(values.Encode()))
-		if err == nil {
-			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		retryReq.Attempt = attempt - failovers
+		retryReq.Sent = sent
+		delay, retry := c.retryPolicy.ShouldRetry(retryReq, err)
+		if !retry {
+			return nil, err
+		}
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, err
+			case <-timer.C:
+			}
 		}
-	} else {
-		return nil, fmt.Errorf("不支持的HTTP方法: %s", method)
 	}
+}
+
+// executeOnce 执行一次HTTP请求（经过拦截器链），probe 表示请求占用了半开状态的探测名额
+// sent 表示请求是否可能已经到达服务器（用于判断非幂等请求能否安全重试）
+func (c *Client) executeOnce(ctx context.Context, spec *requestSpec, ep *endpoint, probe bool, attempt int) (body []byte, sent bool, err error) {
+	// 记录地址健康状况，请求未发出或调用方取消时不计入
+	requested, unhealthy := false, false
+	defer func() {
+		ep.record(spec.pool, probe, requested && (unhealthy || isEndpointFailure(err)), !requested || ctx.Err() != nil, err)
+	}()
+
+	// 时间戳接口不计入全局限流（在构建其他请求的认证参数时调用）
+	if spec.endpoint != EndpointServiceTimestamp {
+		if err := c.waitGlobal(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if spec.auth {
+		if err := c.buildAuthParams(ctx, spec.params); err != nil {
+			return nil, false, c.errorf(msgBuildAuthParams, err)
+		}
+	}
+
+	requestURL := c.buildRequestURL(ep.url, spec.endpoint)
+	req, err := spec.newRequest(ctx, requestURL)
+	if err != nil {
+		return nil, false, c.errorf(msgCreateRequest, err)
+	}
+
+	info := &RequestInfo{
+		Method:    spec.method,
+		Endpoint:  spec.endpoint,
+		URL:       requestURL,
+		Params:    redactParams(spec.params),
+		Header:    req.Header,
+		Multipart: spec.multipart,
+		Attempt:   attempt,
+	}
+
+	// 演练模式下发送接口经过拦截器链后返回合成的响应，不发出请求
+	dryRun := c.dryRun && dryRunEndpoints[spec.endpoint]
+
+	requested = !dryRun
+	resp, err := c.intercept(ctx, info, func(ctx context.Context, info *RequestInfo) (*ResponseInfo, error) {
+		if dryRun {
+			return c.dryRunResponse(ctx, info)
+		}
 
+		var resp *ResponseInfo
+		resp, sent, err = c.roundTrip(req.WithContext(ctx), info.Header)
+		return resp, err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %v", err)
+		return nil, sent, err
+	}
+	if resp == nil {
+		return nil, sent, c.errorf(msgNoResponse, spec.endpoint)
+	}
+	if spec.healthCheck != nil {
+		if err := spec.healthCheck(resp.Body); err != nil {
+			unhealthy = true
+			return nil, true, err
+		}
 	}
 
+	return resp.Body, true, nil
+}
+
+// roundTrip 发送HTTP请求并检查HTTP状态码和API错误
+func (c *Client) roundTrip(req *http.Request, header http.Header) (*ResponseInfo, bool, error) {
+	req.Header = header
+	start := time.Now()
+
+	// 跟踪请求是否已写出：DNS解析、建立连接阶段失败或在此之前取消时，请求一定未发出
+	var traced, wrote atomic.Bool
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GetConn:      func(string) { traced.Store(true) },
+		WroteRequest: func(httptrace.WroteRequestInfo) { wrote.Store(true) },
+	}))
+
 	// 执行请求
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("执行请求失败: %v", err)
+		// 自定义传输层可能不触发跟踪回调，此时只能根据错误类型判断
+		written := !isDialError(err) && (wrote.Load() || !traced.Load())
+		return nil, written, &transportError{locale: c.locale, written: written, err: err}
 	}
 	defer resp.Body.Close()
 
 	// 读取响应
 	body, err := io.ReadAll(resp.Body)
+	info := &ResponseInfo{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Duration:   time.Since(start),
+	}
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %v", err)
+		return info, true, &transportError{locale: c.locale, read: true, written: true, err: err}
 	}
 
 	// 检查HTTP状态码
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP错误: %d - %s", resp.StatusCode, string(body))
+		return info, true, &HTTPStatusError{StatusCode: resp.StatusCode, Body: body, locale: c.locale}
 	}
 
 	// 检查API错误
-	if err := ParseAPIError(body); err != nil {
-		return nil, err
+	if err := c.decoder.DecodeError(body); err != nil {
+		c.localizeAPIError(err)
+		c.handleAPIError(err)
+		return info, true, err
 	}
 
-	return body, nil
+	return info, true, nil
 }
 
 // doJSONRequest 执行JSON请求
-func (c *Client) doJSONRequest(method, endpoint string, data interface{}) ([]byte, error) {
-	return c.doJSONRequestWithBaseURL(method, endpoint, data, c.BaseURL)
+func (c *Client) doJSONRequest(ctx context.Context, method, endpoint string, data interface{}) ([]byte, error) {
+	return c.doJSONRequestWithPool(ctx, method, endpoint, data, c.apiPool)
 }
 
-// doJSONRequestWithBaseURL 使用指定基础URL执行JSON请求
-func (c *Client) doJSONRequestWithBaseURL(method, endpoint string, data interface{}, baseURL string) ([]byte, error) {
+// doJSONRequestWithPool 使用指定地址池执行JSON请求
+func (c *Client) doJSONRequestWithPool(ctx context.Context, method, endpoint string, data interface{}, pool *endpointPool) ([]byte, error) {
 	// 将结构体转换为map[string]string
 	params := make(map[string]string)
 
 	if data != nil {
 		jsonData, err := json.Marshal(data)
 		if err != nil {
-			return nil, fmt.Errorf("序列化请求数据失败: %v", err)
+			return nil, c.errorf(msgMarshalData, err)
 		}
 
 		var dataMap map[string]interface{}
 		if err := json.Unmarshal(jsonData, &dataMap); err != nil {
-			return nil, fmt.Errorf("解析请求数据失败: %v", err)
+			return nil, c.errorf(msgUnmarshalData, err)
 		}
 
 		for k, v := range dataMap {
@@ -580,28 +896,16 @@ func (c *Client) doJSONRequestWithBaseURL(method, endpoint string, data interfac
 		}
 	}
 
-	return c.doRequestWithBaseURL(method, endpoint, params, baseURL)
+	return c.doRequestWithPool(ctx, method, endpoint, params, pool)
 }
 
 // doMultipartFormRequest 执行multipart/form-data请求
-func (c *Client) doMultipartFormRequest(method, endpoint string, data interface{}) ([]byte, error) {
-	return c.doMultipartFormRequestWithBaseURL(method, endpoint, data, c.BaseURL)
+func (c *Client) doMultipartFormRequest(ctx context.Context, method, endpoint string, data interface{}) ([]byte, error) {
+	return c.doMultipartFormRequestWithPool(ctx, method, endpoint, data, c.apiPool)
 }
 
-// doMultipartFormRequestWithBaseURL 使用指定基础URL执行multipart/form-data请求
-func (c *Client) doMultipartFormRequestWithBaseURL(method, endpoint string, data interface{}, baseURL string) ([]byte, error) {
-	// 构建URL
-	requestURL := baseURL + endpoint
-	if c.format == FormatXML {
-		requestURL += ".xml"
-	} else {
-		requestURL += ".json"
-	}
-
-	// 创建multipart writer
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
-
+// doMultipartFormRequestWithPool 使用指定地址池执行multipart/form-data请求
+func (c *Client) doMultipartFormRequestWithPool(ctx context.Context, method, endpoint string, data interface{}, pool *endpointPool) ([]byte, error) {
 	// 使用反射处理结构体字段
 	v := reflect.ValueOf(data)
 	if v.Kind() == reflect.Ptr {
@@ -610,6 +914,7 @@ func (c *Client) doMultipartFormRequestWithBaseURL(method, endpoint string, data
 	t := v.Type()
 
 	params := make(map[string]string)
+	var fileHeaders []*multipart.FileHeader
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -631,25 +936,7 @@ func (c *Client) doMultipartFormRequestWithBaseURL(method, endpoint string, data
 
 		// 处理文件字段
 		if formTag == "attachments" && field.Type() == reflect.TypeOf([]*multipart.FileHeader{}) {
-			fileHeaders := field.Interface().([]*multipart.FileHeader)
-			for _, fh := range fileHeaders {
-				if fh != nil {
-					file, err := fh.Open()
-					if err != nil {
-						return nil, fmt.Errorf("打开文件失败: %v", err)
-					}
-					defer file.Close()
-
-					fileWriter, err := writer.CreateFormFile("attachments", fh.Filename)
-					if err != nil {
-						return nil, fmt.Errorf("创建文件字段失败: %v", err)
-					}
-
-					if _, err := io.Copy(fileWriter, file); err != nil {
-						return nil, fmt.Errorf("写入文件数据失败: %v", err)
-					}
-				}
-			}
+			fileHeaders = append(fileHeaders, field.Interface().([]*multipart.FileHeader)...)
 		} else {
 			// 处理普通字段
 			var value string
@@ -680,96 +967,118 @@ func (c *Client) doMultipartFormRequestWithBaseURL(method, endpoint string, data
 		}
 	}
 
-	// 添加认证参数
-	if err := c.buildAuthParams(params); err != nil {
-		return nil, err
+	spec := &requestSpec{
+		method:    method,
+		endpoint:  endpoint,
+		pool:      pool,
+		params:    params,
+		auth:      true,
+		multipart: true,
 	}
 
-	// 添加普通表单字段
-	for key, value := range params {
-		if err := writer.WriteField(key, value); err != nil {
-			return nil, fmt.Errorf("添加表单字段失败: %v", err)
+	// 每次尝试都重新构建请求体（文件需要重新读取）
+	spec.newRequest = func(ctx context.Context, requestURL string) (*http.Request, error) {
+		// 创建multipart writer
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+
+		for _, fh := range fileHeaders {
+			if fh == nil {
+				continue
+			}
+			if err := c.writeMultipartFile(writer, fh); err != nil {
+				return nil, err
+			}
 		}
-	}
 
-	// 关闭multipart writer
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("关闭multipart writer失败: %v", err)
-	}
+		// 添加普通表单字段
+		for key, value := range params {
+			if err := writer.WriteField(key, value); err != nil {
+				return nil, c.errorf(msgAddFormField, err)
+			}
+		}
 
-	// 创建HTTP请求
-	req, err := http.NewRequest(method, requestURL, &body)
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %v", err)
-	}
+		// 关闭multipart writer
+		if err := writer.Close(); err != nil {
+			return nil, c.errorf(msgCloseMultipart, err)
+		}
 
-	// 设置Content-Type
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+		// 创建HTTP请求
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, &body)
+		if err != nil {
+			return nil, err
+		}
 
-	// 执行请求
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("执行请求失败: %v", err)
+		// 设置Content-Type
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req, nil
 	}
-	defer resp.Body.Close()
 
-	// 读取响应
-	responseBody, err := io.ReadAll(resp.Body)
+	return c.execute(ctx, spec)
+}
+
+// writeMultipartFile 将上传文件写入multipart表单
+func (c *Client) writeMultipartFile(writer *multipart.Writer, fh *multipart.FileHeader) error {
+	file, err := fh.Open()
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %v", err)
+		return c.errorf(msgOpenFile, err)
 	}
+	defer file.Close()
 
-	// 检查HTTP状态码
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP错误: %d - %s", resp.StatusCode, string(responseBody))
+	fileWriter, err := writer.CreateFormFile("attachments", fh.Filename)
+	if err != nil {
+		return c.errorf(msgCreateFileField, err)
 	}
 
-	// 检查API错误
-	if err := ParseAPIError(responseBody); err != nil {
-		return nil, err
+	if _, err := io.Copy(fileWriter, file); err != nil {
+		return c.errorf(msgWriteFile, err)
 	}
-
-	return responseBody, nil
+	return nil
 }
 
 // ===== 工具类API =====
 
 // ServiceTimestamp 获取服务器时间戳
 func (c *Client) ServiceTimestamp() (*ServiceTimestampResponse, error) {
+	return c.ServiceTimestampCtx(context.Background())
+}
+
+// ServiceTimestampCtx 获取服务器时间戳（支持 context 取消与超时控制）
+func (c *Client) ServiceTimestampCtx(ctx context.Context) (*ServiceTimestampResponse, error) {
 	// Service/Timestamp API 不需要授权参数
 	params := make(map[string]string)
 
-	body, err := c.doRequest("GET", EndpointServiceTimestamp, params)
+	body, err := c.doRequest(ctx, "GET", EndpointServiceTimestamp, params)
 	if err != nil {
-		return nil, fmt.Errorf("请求时间戳API失败: %v", err)
+		return nil, c.errorf(msgTimestampRequest, err)
 	}
 
 	// 检查响应是否为空
 	if len(body) == 0 {
-		return nil, fmt.Errorf("时间戳API返回空响应")
+		return nil, c.decodeError(targetTimestamp, body, c.errorf(msgEmptyResponse))
 	}
 
 	// 先尝试解析可能的错误响应
 	var errorResp struct {
-		Status string `json:"status"`
-		Code   int    `json:"code"`
-		Msg    string `json:"msg"`
+		Status string `json:"status" xml:"status"`
+		Code   int    `json:"code" xml:"code"`
+		Msg    string `json:"msg" xml:"msg"`
 	}
-	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Status == "error" {
-		return nil, fmt.Errorf("时间戳API返回错误: code=%d, msg=%s", errorResp.Code, errorResp.Msg)
+	if err := c.decoder.Decode(body, &errorResp); err == nil && errorResp.Status == "error" {
+		return nil, c.errorf(msgTimestampAPIError, NewAPIErrorWithLocale(c.locale, errorResp.Code, errorResp.Msg))
 	}
 
-	// 解析正常的时间戳响应，格式为 {"timestamp": 1414253462}
+	// 解析正常的时间戳响应，JSON格式为 {"timestamp": 1414253462}，XML格式为 <root><timestamp>1414253462</timestamp></root>
 	var timestampResp struct {
-		Timestamp int64 `json:"timestamp"`
+		Timestamp int64 `json:"timestamp" xml:"timestamp"`
 	}
-	if err := json.Unmarshal(body, &timestampResp); err != nil {
-		return nil, fmt.Errorf("解析时间戳响应失败: %v, 响应内容: %s", err, string(body))
+	if err := c.decoder.Decode(body, &timestampResp); err != nil {
+		return nil, c.decodeError(targetTimestamp, body, err)
 	}
 
 	// 检查时间戳是否有效
 	if timestampResp.Timestamp == 0 {
-		return nil, fmt.Errorf("获取到无效的时间戳: %d, 响应内容: %s", timestampResp.Timestamp, string(body))
+		return nil, c.decodeError(targetTimestamp, body, c.errorf(msgInvalidTimestamp, timestampResp.Timestamp))
 	}
 
 	return &ServiceTimestampResponse{
@@ -780,7 +1089,12 @@ func (c *Client) ServiceTimestamp() (*ServiceTimestampResponse, error) {
 
 // GetCurrentTimestamp 获取当前服务器时间戳（便捷方法）
 func (c *Client) GetCurrentTimestamp() (int64, error) {
-	resp, err := c.ServiceTimestamp()
+	return c.GetCurrentTimestampCtx(context.Background())
+}
+
+// GetCurrentTimestampCtx 获取当前服务器时间戳（便捷方法，支持 context 取消与超时控制）
+func (c *Client) GetCurrentTimestampCtx(ctx context.Context) (int64, error) {
+	resp, err := c.ServiceTimestampCtx(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -789,13 +1103,18 @@ func (c *Client) GetCurrentTimestamp() (int64, error) {
 
 // DiagnoseConnection 诊断网络连接问题
 func (c *Client) DiagnoseConnection() error {
+	return c.DiagnoseConnectionCtx(context.Background())
+}
+
+// DiagnoseConnectionCtx 诊断网络连接问题（支持 context 取消与超时控制）
+func (c *Client) DiagnoseConnectionCtx(ctx context.Context) error {
 	fmt.Printf("正在诊断SUBMAIL API连接...\n")
 	fmt.Printf("基础URL: %s\n", c.BaseURL)
 	fmt.Printf("超时设置: %v\n", c.timeout)
 
 	// 测试时间戳API
 	fmt.Printf("\n1. 测试时间戳API...\n")
-	timestampResp, err := c.ServiceTimestamp()
+	timestampResp, err := c.ServiceTimestampCtx(ctx)
 	if err != nil {
 		fmt.Printf("❌ 时间戳API测试失败: %v\n", err)
 		return err
@@ -804,7 +1123,7 @@ func (c *Client) DiagnoseConnection() error {
 
 	// 测试服务状态API
 	fmt.Printf("\n2. 测试服务状态API...\n")
-	statusResp, err := c.ServiceStatus()
+	statusResp, err := c.ServiceStatusCtx(ctx)
 	if err != nil {
 		fmt.Printf("❌ 服务状态API测试失败: %v\n", err)
 		return err
@@ -818,17 +1137,22 @@ func (c *Client) DiagnoseConnection() error {
 
 // ServiceStatus 获取服务器状态
 func (c *Client) ServiceStatus() (*ServiceStatusResponse, error) {
+	return c.ServiceStatusCtx(context.Background())
+}
+
+// ServiceStatusCtx 获取服务器状态（支持 context 取消与超时控制）
+func (c *Client) ServiceStatusCtx(ctx context.Context) (*ServiceStatusResponse, error) {
 	// Service/Status API 不需要授权参数
 	params := make(map[string]string)
 
-	body, err := c.doRequest("GET", EndpointServiceStatus, params)
+	body, err := c.doRequest(ctx, "GET", EndpointServiceStatus, params)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp ServiceStatusResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析服务状态响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetServiceStatus, body, err)
 	}
 
 	return &resp, nil
@@ -836,7 +1160,12 @@ func (c *Client) ServiceStatus() (*ServiceStatusResponse, error) {
 
 // IsServiceRunning 检查服务是否正常运行（便捷方法）
 func (c *Client) IsServiceRunning() (bool, error) {
-	resp, err := c.ServiceStatus()
+	return c.IsServiceRunningCtx(context.Background())
+}
+
+// IsServiceRunningCtx 检查服务是否正常运行（便捷方法，支持 context 取消与超时控制）
+func (c *Client) IsServiceRunningCtx(ctx context.Context) (bool, error) {
+	resp, err := c.ServiceStatusCtx(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -845,29 +1174,139 @@ func (c *Client) IsServiceRunning() (bool, error) {
 
 // GetServiceRuntime 获取服务响应时间（便捷方法）
 func (c *Client) GetServiceRuntime() (float64, error) {
-	resp, err := c.ServiceStatus()
+	return c.GetServiceRuntimeCtx(context.Background())
+}
+
+// GetServiceRuntimeCtx 获取服务响应时间（便捷方法，支持 context 取消与超时控制）
+func (c *Client) GetServiceRuntimeCtx(ctx context.Context) (float64, error) {
+	resp, err := c.ServiceStatusCtx(ctx)
 	if err != nil {
 		return 0, err
 	}
 	return resp.Runtime, nil
 }
 
+// ===== 发送前检查 =====
+
+// beforeSend 发送类接口在请求发出前的检查（手机号码校验、按手机号限流等）
+// 返回实际发送的收件人：校验号码时为规范化后的号码（中国大陆手机号为11位号码，国际号码为 E.164 格式），否则为原值
+// 号段表未收录的中国大陆手机号（如新放号的号段）不视为无效，记录警告后发送，由服务器判断
+func (c *Client) beforeSend(ctx context.Context, recipients []string) ([]string, error) {
+	if c.validatePhone {
+		if len(recipients) == 0 {
+			return nil, c.validationError("to", msgInvalidPhone, "")
+		}
+		normalized := make([]string, len(recipients))
+		for i, to := range recipients {
+			number, err := phone.ParseLenient(to)
+			if err != nil {
+				var parseErr *phone.ParseError
+				if errors.As(err, &parseErr) {
+					return nil, &ValidationError{Field: "to", Message: localizeParseError(c.locale, parseErr)}
+				}
+				return nil, c.validationError("to", msgInvalidPhone, to)
+			}
+			if number.Unlisted {
+				c.logf(msgUnlistedPhonePrefix, to, number.National[:3])
+			}
+			normalized[i] = number.String()
+		}
+		recipients = normalized
+	}
+	return recipients, c.waitRecipients(ctx, recipients)
+}
+
+// logf 使用客户端的语言记录警告日志
+func (c *Client) logf(key string, args ...interface{}) {
+	c.logger.Printf("[submail] %s", localizef(c.locale, key, args...))
+}
+
+// withRecipients 返回替换收件人后的一对多收件人列表副本
+func withRecipients[T any](items []T, recipients []string, setTo func(item *T, to string)) []T {
+	replaced := append([]T(nil), items...)
+	for i := range replaced {
+		setTo(&replaced[i], recipients[i])
+	}
+	return replaced
+}
+
+// splitRecipients 拆分逗号分隔的手机号
+func splitRecipients(to string) []string {
+	var recipients []string
+	for _, phone := range strings.Split(to, ",") {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			recipients = append(recipients, phone)
+		}
+	}
+	return recipients
+}
+
+// multiRecipients 获取一对多发送的收件人
+func multiRecipients(items []SMSMultiItem) []string {
+	recipients := make([]string, 0, len(items))
+	for _, item := range items {
+		recipients = append(recipients, item.To)
+	}
+	return recipients
+}
+
+// multiXRecipients 获取模板一对多发送的收件人
+func multiXRecipients(items []SMSMultiXItem) []string {
+	recipients := make([]string, 0, len(items))
+	for _, item := range items {
+		recipients = append(recipients, item.To)
+	}
+	return recipients
+}
+
 // ===== 短信发送API =====
 
 // SMSSend 短信发送
 func (c *Client) SMSSend(req *SMSSendRequest) (*SMSSendResponse, error) {
+	return c.SMSSendCtx(context.Background(), req)
+}
+
+// SMSSendCtx 短信发送（支持 context 取消与超时控制）
+//
+// 收件人在屏蔽名单中时不发出请求，返回 nil 响应与 *SuppressedError（可用 errors.Is(err, ErrSuppressed) 判断）
+func (c *Client) SMSSendCtx(ctx context.Context, req *SMSSendRequest) (*SMSSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	content, err := c.marketingContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	if content != req.Content {
+		prepared := *req
+		prepared.Content = content
+		req = &prepared
+	}
+
+	if err := c.suppressSingle(ctx, req.To); err != nil {
+		return nil, err
+	}
+
+	recipients, err := c.beforeSend(ctx, []string{req.To})
+	if err != nil {
+		return nil, err
+	}
+	if recipients[0] != req.To {
+		normalized := *req
+		normalized.To = recipients[0]
+		req = &normalized
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSSend, req)
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSSend, req)
 	if err != nil {
+		c.learnSuppressionError(ctx, req.To, err)
 		return nil, err
 	}
 
 	var resp SMSSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信发送响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSSend, body, err)
 	}
 
 	return &resp, nil
@@ -875,18 +1314,40 @@ func (c *Client) SMSSend(req *SMSSendRequest) (*SMSSendResponse, error) {
 
 // SMSXSend 短信模板发送
 func (c *Client) SMSXSend(req *SMSXSendRequest) (*SMSSendResponse, error) {
+	return c.SMSXSendCtx(context.Background(), req)
+}
+
+// SMSXSendCtx 短信模板发送（支持 context 取消与超时控制）
+//
+// 收件人在屏蔽名单中时不发出请求，返回 nil 响应与 *SuppressedError（可用 errors.Is(err, ErrSuppressed) 判断）
+func (c *Client) SMSXSendCtx(ctx context.Context, req *SMSXSendRequest) (*SMSSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	if err := c.suppressSingle(ctx, req.To); err != nil {
+		return nil, err
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSXSend, req)
+	recipients, err := c.beforeSend(ctx, []string{req.To})
 	if err != nil {
 		return nil, err
 	}
+	if recipients[0] != req.To {
+		normalized := *req
+		normalized.To = recipients[0]
+		req = &normalized
+	}
+
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSXSend, req)
+	if err != nil {
+		c.learnSuppressionError(ctx, req.To, err)
+		return nil, err
+	}
 
 	var resp SMSSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信模板发送响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSXSend, body, err)
 	}
 
 	return &resp, nil
@@ -894,94 +1355,276 @@ func (c *Client) SMSXSend(req *SMSXSendRequest) (*SMSSendResponse, error) {
 
 // SMSMultiSend 短信一对多发送
 func (c *Client) SMSMultiSend(req *SMSMultiSendRequest) (*SMSMultiSendResponse, error) {
+	return c.SMSMultiSendCtx(context.Background(), req)
+}
+
+// SMSMultiSendCtx 短信一对多发送（支持 context 取消与超时控制）
+//
+// 屏蔽名单中的收件人不发出请求，以 Status 为 skipped 的结果返回，不视为错误
+func (c *Client) SMSMultiSendCtx(ctx context.Context, req *SMSMultiSendRequest) (*SMSMultiSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	content, err := c.marketingContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	if content != req.Content {
+		prepared := *req
+		prepared.Content = content
+		req = &prepared
+	}
+
+	total := len(req.Multi)
+	skipped, err := c.suppressed(ctx, multiRecipients(req.Multi))
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		filtered := *req
+		filtered.Multi = withoutSkipped(req.Multi, skipped)
+		if len(filtered.Multi) == 0 {
+			resp := SMSMultiSendResponse(mergeSkipped(nil, skipped, total))
+			return &resp, nil
+		}
+		req = &filtered
+	}
+
+	recipients, err := c.beforeSend(ctx, multiRecipients(req.Multi))
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(recipients, multiRecipients(req.Multi)) {
+		normalized := *req
+		normalized.Multi = withRecipients(req.Multi, recipients, func(item *SMSMultiItem, to string) { item.To = to })
+		req = &normalized
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSMultiSend, req)
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSMultiSend, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSMultiSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信一对多发送响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSMultiSend, body, err)
 	}
 
+	c.learnSuppression(ctx, resp)
+	resp = mergeSkipped(resp, skipped, total)
 	return &resp, nil
 }
 
 // SMSMultiXSend 短信模板一对多发送
 func (c *Client) SMSMultiXSend(req *SMSMultiXSendRequest) (*SMSMultiSendResponse, error) {
+	return c.SMSMultiXSendCtx(context.Background(), req)
+}
+
+// SMSMultiXSendCtx 短信模板一对多发送（支持 context 取消与超时控制）
+//
+// 屏蔽名单中的收件人不发出请求，以 Status 为 skipped 的结果返回，不视为错误
+func (c *Client) SMSMultiXSendCtx(ctx context.Context, req *SMSMultiXSendRequest) (*SMSMultiSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	total := len(req.Multi)
+	skipped, err := c.suppressed(ctx, multiXRecipients(req.Multi))
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		filtered := *req
+		filtered.Multi = withoutSkipped(req.Multi, skipped)
+		if len(filtered.Multi) == 0 {
+			resp := SMSMultiSendResponse(mergeSkipped(nil, skipped, total))
+			return &resp, nil
+		}
+		req = &filtered
+	}
+
+	recipients, err := c.beforeSend(ctx, multiXRecipients(req.Multi))
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(recipients, multiXRecipients(req.Multi)) {
+		normalized := *req
+		normalized.Multi = withRecipients(req.Multi, recipients, func(item *SMSMultiXItem, to string) { item.To = to })
+		req = &normalized
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSMultiXSend, req)
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSMultiXSend, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSMultiSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信模板一对多发送响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSMultiXSend, body, err)
 	}
 
+	c.learnSuppression(ctx, resp)
+	resp = mergeSkipped(resp, skipped, total)
 	return &resp, nil
 }
 
 // SMSBatchSend 短信批量群发
 func (c *Client) SMSBatchSend(req *SMSBatchSendRequest) (*SMSBatchSendResponse, error) {
+	return c.SMSBatchSendCtx(context.Background(), req)
+}
+
+// SMSBatchSendCtx 短信批量群发（支持 context 取消与超时控制）
+//
+// 屏蔽名单中的收件人不发出请求，以 Status 为 skipped 的结果返回，不视为错误；全部被屏蔽时响应 Status 为 skipped
+func (c *Client) SMSBatchSendCtx(ctx context.Context, req *SMSBatchSendRequest) (*SMSBatchSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	content, err := c.marketingContent(req.Content)
+	if err != nil {
+		return nil, err
+	}
+	if content != req.Content {
+		prepared := *req
+		prepared.Content = content
+		req = &prepared
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSBatchSend, req)
+	recipients := splitRecipients(req.To)
+	skipped, err := c.suppressed(ctx, recipients)
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		filtered := *req
+		filtered.To = strings.Join(withoutSkipped(recipients, skipped), ",")
+		if filtered.To == "" {
+			return &SMSBatchSendResponse{
+				BaseResponse: BaseResponse{Status: SendStatusSkipped},
+				Responses:    mergeSkipped(nil, skipped, len(recipients)),
+			}, nil
+		}
+		req = &filtered
+	}
+
+	sendTo, err := c.beforeSend(ctx, splitRecipients(req.To))
+	if err != nil {
+		return nil, err
+	}
+	if to := strings.Join(sendTo, ","); to != req.To {
+		normalized := *req
+		normalized.To = to
+		req = &normalized
+	}
+
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSBatchSend, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSBatchSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信批量群发响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSBatchSend, body, err)
 	}
 
+	c.learnSuppression(ctx, resp.Responses)
+	resp.Responses = mergeSkipped(resp.Responses, skipped, len(recipients))
 	return &resp, nil
 }
 
 // SMSBatchXSend 短信批量模板群发
 func (c *Client) SMSBatchXSend(req *SMSBatchXSendRequest) (*SMSBatchSendResponse, error) {
+	return c.SMSBatchXSendCtx(context.Background(), req)
+}
+
+// SMSBatchXSendCtx 短信批量模板群发（支持 context 取消与超时控制）
+//
+// 屏蔽名单中的收件人不发出请求，以 Status 为 skipped 的结果返回，不视为错误；全部被屏蔽时响应 Status 为 skipped
+func (c *Client) SMSBatchXSendCtx(ctx context.Context, req *SMSBatchXSendRequest) (*SMSBatchSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSBatchXSend, req)
+	recipients := splitRecipients(req.To)
+	skipped, err := c.suppressed(ctx, recipients)
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		filtered := *req
+		filtered.To = strings.Join(withoutSkipped(recipients, skipped), ",")
+		if filtered.To == "" {
+			return &SMSBatchSendResponse{
+				BaseResponse: BaseResponse{Status: SendStatusSkipped},
+				Responses:    mergeSkipped(nil, skipped, len(recipients)),
+			}, nil
+		}
+		req = &filtered
+	}
+
+	sendTo, err := c.beforeSend(ctx, splitRecipients(req.To))
+	if err != nil {
+		return nil, err
+	}
+	if to := strings.Join(sendTo, ","); to != req.To {
+		normalized := *req
+		normalized.To = to
+		req = &normalized
+	}
+
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSBatchXSend, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSBatchSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信批量模板群发响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSBatchXSend, body, err)
 	}
 
+	c.learnSuppression(ctx, resp.Responses)
+	resp.Responses = mergeSkipped(resp.Responses, skipped, len(recipients))
 	return &resp, nil
 }
 
 // SMSUnionSend 国内短信与国际短信联合发送
 func (c *Client) SMSUnionSend(req *SMSUnionSendRequest) (*SMSSendResponse, error) {
+	return c.SMSUnionSendCtx(context.Background(), req)
+}
+
+// SMSUnionSendCtx 国内短信与国际短信联合发送（支持 context 取消与超时控制）
+//
+// 收件人在屏蔽名单中时不发出请求，返回 nil 响应与 *SuppressedError（可用 errors.Is(err, ErrSuppressed) 判断）
+func (c *Client) SMSUnionSendCtx(ctx context.Context, req *SMSUnionSendRequest) (*SMSSendResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
+	}
+
+	if err := c.suppressSingle(ctx, req.To); err != nil {
+		return nil, err
+	}
+
+	recipients, err := c.beforeSend(ctx, []string{req.To})
+	if err != nil {
+		return nil, err
+	}
+	if recipients[0] != req.To {
+		normalized := *req
+		normalized.To = recipients[0]
+		req = &normalized
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSUnionSend, req)
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSUnionSend, req)
 	if err != nil {
+		c.learnSuppressionError(ctx, req.To, err)
 		return nil, err
 	}
 
 	var resp SMSSendResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信联合发送响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSUnionSend, body, err)
 	}
 
 	return &resp, nil
@@ -989,6 +1632,11 @@ func (c *Client) SMSUnionSend(req *SMSUnionSendRequest) (*SMSSendResponse, error
 
 // SMSUnionSendWithConfig 国内外短信联合发送（便捷方法）
 func (c *Client) SMSUnionSendWithConfig(to, content, interAppID, interSignature string, interContent, tag string, enableCodeTransform bool) (*SMSSendResponse, error) {
+	return c.SMSUnionSendWithConfigCtx(context.Background(), to, content, interAppID, interSignature, interContent, tag, enableCodeTransform)
+}
+
+// SMSUnionSendWithConfigCtx 国内外短信联合发送（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSUnionSendWithConfigCtx(ctx context.Context, to, content, interAppID, interSignature string, interContent, tag string, enableCodeTransform bool) (*SMSSendResponse, error) {
 	codeTransform := "false"
 	if enableCodeTransform {
 		codeTransform = "true"
@@ -1004,40 +1652,34 @@ func (c *Client) SMSUnionSendWithConfig(to, content, interAppID, interSignature
 		Tag:                         tag,
 	}
 
-	return c.SMSUnionSend(req)
+	return c.SMSUnionSendCtx(ctx, req)
 }
 
-// IsInternationalNumber 判断是否为国际号码
+// IsInternationalNumber 判断是否为有效的国际号码（非中国大陆），等同于 phone.IsInternational
+// 早期版本仅按格式粗略判断（如11位以外的数字视为国际号码），现与 phone 包及发送接口的判断保持一致，无效号码返回 false
+//
+// Deprecated: 使用 phone.IsInternational，或通过 phone.Parse 获取号码详情
 func IsInternationalNumber(phoneNumber string) bool {
-	// 国际号码以+开头且不是+86
-	if len(phoneNumber) > 3 && phoneNumber[0] == '+' {
-		return phoneNumber[:3] != "+86"
-	}
-	// 11位数字为国内号码
-	if len(phoneNumber) == 11 {
-		for _, r := range phoneNumber {
-			if r < '0' || r > '9' {
-				return true // 包含非数字字符，可能是国际号码
-			}
-		}
-		return false // 纯11位数字，国内号码
-	}
-	// 其他情况视为国际号码
-	return true
+	return phone.IsInternational(phoneNumber)
 }
 
 // ===== 短信签名管理API =====
 
 // SMSSignatureQuery 查询短信签名
 func (c *Client) SMSSignatureQuery(req *SMSSignatureQueryRequest) (*SMSSignatureQueryResponse, error) {
-	body, err := c.doJSONRequest("GET", EndpointSMSAppextend, req)
+	return c.SMSSignatureQueryCtx(context.Background(), req)
+}
+
+// SMSSignatureQueryCtx 查询短信签名（支持 context 取消与超时控制）
+func (c *Client) SMSSignatureQueryCtx(ctx context.Context, req *SMSSignatureQueryRequest) (*SMSSignatureQueryResponse, error) {
+	body, err := c.doJSONRequest(ctx, "GET", EndpointSMSAppextend, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSSignatureQueryResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信签名查询响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSignatureQuery, body, err)
 	}
 
 	return &resp, nil
@@ -1045,23 +1687,28 @@ func (c *Client) SMSSignatureQuery(req *SMSSignatureQueryRequest) (*SMSSignature
 
 // SMSSignatureCreate 创建短信签名
 func (c *Client) SMSSignatureCreate(req *SMSSignatureCreateRequest) (*SMSSignatureOperationResponse, error) {
+	return c.SMSSignatureCreateCtx(context.Background(), req)
+}
+
+// SMSSignatureCreateCtx 创建短信签名（支持 context 取消与超时控制）
+func (c *Client) SMSSignatureCreateCtx(ctx context.Context, req *SMSSignatureCreateRequest) (*SMSSignatureOperationResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
 	// 检查必填的文件参数
 	if len(req.Attachments) == 0 {
-		return nil, fmt.Errorf("必须提供证明材料文件")
+		return nil, c.validationError("attachments", msgAttachmentsRequired)
 	}
 
-	body, err := c.doMultipartFormRequest("POST", EndpointSMSAppextend, req)
+	body, err := c.doMultipartFormRequest(ctx, "POST", EndpointSMSAppextend, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSSignatureOperationResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信签名创建响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSignatureCreate, body, err)
 	}
 
 	return &resp, nil
@@ -1069,25 +1716,30 @@ func (c *Client) SMSSignatureCreate(req *SMSSignatureCreateRequest) (*SMSSignatu
 
 // SMSSignatureUpdate 更新短信签名
 func (c *Client) SMSSignatureUpdate(req *SMSSignatureUpdateRequest) (*SMSSignatureOperationResponse, error) {
+	return c.SMSSignatureUpdateCtx(context.Background(), req)
+}
+
+// SMSSignatureUpdateCtx 更新短信签名（支持 context 取消与超时控制）
+func (c *Client) SMSSignatureUpdateCtx(ctx context.Context, req *SMSSignatureUpdateRequest) (*SMSSignatureOperationResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
 	if req.SMSSignature == "" {
-		return nil, fmt.Errorf("短信签名不能为空")
+		return nil, c.validationError("sms_signature", msgSignatureRequired)
 	}
 
 	// 检查是否需要上传文件
 	if len(req.Attachments) > 0 {
 		// 使用multipart请求
-		body, err := c.doMultipartFormRequest("PUT", EndpointSMSAppextend, req)
+		body, err := c.doMultipartFormRequest(ctx, "PUT", EndpointSMSAppextend, req)
 		if err != nil {
 			return nil, err
 		}
 
 		var resp SMSSignatureOperationResponse
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("解析短信签名更新响应失败: %v", err)
+		if err := c.decoder.Decode(body, &resp); err != nil {
+			return nil, c.decodeError(targetSignatureUpdate, body, err)
 		}
 
 		return &resp, nil
@@ -1123,14 +1775,14 @@ func (c *Client) SMSSignatureUpdate(req *SMSSignatureUpdateRequest) (*SMSSignatu
 			params["contact"] = req.Contact
 		}
 
-		body, err := c.doRequest("PUT", EndpointSMSAppextend, params)
+		body, err := c.doRequest(ctx, "PUT", EndpointSMSAppextend, params)
 		if err != nil {
 			return nil, err
 		}
 
 		var resp SMSSignatureOperationResponse
-		if err := json.Unmarshal(body, &resp); err != nil {
-			return nil, fmt.Errorf("解析短信签名更新响应失败: %v", err)
+		if err := c.decoder.Decode(body, &resp); err != nil {
+			return nil, c.decodeError(targetSignatureUpdate, body, err)
 		}
 
 		return &resp, nil
@@ -1139,8 +1791,13 @@ func (c *Client) SMSSignatureUpdate(req *SMSSignatureUpdateRequest) (*SMSSignatu
 
 // SMSSignatureDelete 删除短信签名
 func (c *Client) SMSSignatureDelete(req *SMSSignatureDeleteRequest) (*SMSSignatureOperationResponse, error) {
+	return c.SMSSignatureDeleteCtx(context.Background(), req)
+}
+
+// SMSSignatureDeleteCtx 删除短信签名（支持 context 取消与超时控制）
+func (c *Client) SMSSignatureDeleteCtx(ctx context.Context, req *SMSSignatureDeleteRequest) (*SMSSignatureOperationResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
 	// 构建请求参数，添加 action 参数标识删除操作
@@ -1152,14 +1809,14 @@ func (c *Client) SMSSignatureDelete(req *SMSSignatureDeleteRequest) (*SMSSignatu
 		params["target_appid"] = req.TargetAppID
 	}
 
-	body, err := c.doRequest("POST", EndpointSMSAppextend, params)
+	body, err := c.doRequest(ctx, "POST", EndpointSMSAppextend, params)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSSignatureOperationResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信签名删除响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSignatureDelete, body, err)
 	}
 
 	return &resp, nil
@@ -1167,42 +1824,31 @@ func (c *Client) SMSSignatureDelete(req *SMSSignatureDeleteRequest) (*SMSSignatu
 
 // GetSignatureStatus 获取签名状态描述
 func GetSignatureStatus(status int) string {
-	switch status {
-	case 0:
-		return "审核中"
-	case 1:
-		return "审核通过"
-	default:
-		return "审核不通过"
-	}
+	return GetSignatureStatusWithLocale(LocaleZhCN, status)
 }
 
 // GetSourceTypeDescription 获取材料类型描述
 func GetSourceTypeDescription(sourceType int) string {
-	switch sourceType {
-	case 0:
-		return "营业执照"
-	case 1:
-		return "商标"
-	case 2:
-		return "APP"
-	default:
-		return "未知类型"
-	}
+	return GetSourceTypeDescriptionWithLocale(LocaleZhCN, sourceType)
 }
 
 // ===== 短信管理API =====
 
 // SMSTemplateGet 获取短信模板列表或单个模板
 func (c *Client) SMSTemplateGet(req *SMSTemplateGetRequest) (*SMSTemplateGetResponse, error) {
-	body, err := c.doJSONRequest("GET", EndpointSMSTemplate, req)
+	return c.SMSTemplateGetCtx(context.Background(), req)
+}
+
+// SMSTemplateGetCtx 获取短信模板列表或单个模板（支持 context 取消与超时控制）
+func (c *Client) SMSTemplateGetCtx(ctx context.Context, req *SMSTemplateGetRequest) (*SMSTemplateGetResponse, error) {
+	body, err := c.doJSONRequest(ctx, "GET", EndpointSMSTemplate, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSTemplateGetResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信模板查询响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetTemplateGet, body, err)
 	}
 
 	return &resp, nil
@@ -1210,18 +1856,23 @@ func (c *Client) SMSTemplateGet(req *SMSTemplateGetRequest) (*SMSTemplateGetResp
 
 // SMSTemplateCreate 创建短信模板
 func (c *Client) SMSTemplateCreate(req *SMSTemplateCreateRequest) (*SMSTemplateCreateResponse, error) {
+	return c.SMSTemplateCreateCtx(context.Background(), req)
+}
+
+// SMSTemplateCreateCtx 创建短信模板（支持 context 取消与超时控制）
+func (c *Client) SMSTemplateCreateCtx(ctx context.Context, req *SMSTemplateCreateRequest) (*SMSTemplateCreateResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSMSTemplate, req)
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSTemplate, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSTemplateCreateResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信模板创建响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetTemplateCreate, body, err)
 	}
 
 	return &resp, nil
@@ -1229,18 +1880,23 @@ func (c *Client) SMSTemplateCreate(req *SMSTemplateCreateRequest) (*SMSTemplateC
 
 // SMSTemplateUpdate 更新短信模板
 func (c *Client) SMSTemplateUpdate(req *SMSTemplateUpdateRequest) (*SMSTemplateOperationResponse, error) {
+	return c.SMSTemplateUpdateCtx(context.Background(), req)
+}
+
+// SMSTemplateUpdateCtx 更新短信模板（支持 context 取消与超时控制）
+func (c *Client) SMSTemplateUpdateCtx(ctx context.Context, req *SMSTemplateUpdateRequest) (*SMSTemplateOperationResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
-	body, err := c.doJSONRequest("PUT", EndpointSMSTemplate, req)
+	body, err := c.doJSONRequest(ctx, "PUT", EndpointSMSTemplate, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSTemplateOperationResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信模板更新响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetTemplateUpdate, body, err)
 	}
 
 	return &resp, nil
@@ -1248,19 +1904,24 @@ func (c *Client) SMSTemplateUpdate(req *SMSTemplateUpdateRequest) (*SMSTemplateO
 
 // SMSTemplateDelete 删除短信模板
 func (c *Client) SMSTemplateDelete(req *SMSTemplateDeleteRequest) (*SMSTemplateOperationResponse, error) {
+	return c.SMSTemplateDeleteCtx(context.Background(), req)
+}
+
+// SMSTemplateDeleteCtx 删除短信模板（支持 context 取消与超时控制）
+func (c *Client) SMSTemplateDeleteCtx(ctx context.Context, req *SMSTemplateDeleteRequest) (*SMSTemplateOperationResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
 	// 根据官方文档，DELETE 请求参数应该放在请求体中（使用 --data）
-	body, err := c.doJSONRequest("DELETE", EndpointSMSTemplate, req)
+	body, err := c.doJSONRequest(ctx, "DELETE", EndpointSMSTemplate, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSTemplateOperationResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信模板删除响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetTemplateDelete, body, err)
 	}
 
 	return &resp, nil
@@ -1268,18 +1929,7 @@ func (c *Client) SMSTemplateDelete(req *SMSTemplateDeleteRequest) (*SMSTemplateO
 
 // GetTemplateStatus 获取模板状态描述
 func GetTemplateStatus(status string) string {
-	switch status {
-	case "0":
-		return "未提交审核"
-	case "1":
-		return "正在审核"
-	case "2":
-		return "审核通过"
-	case "3":
-		return "未通过审核"
-	default:
-		return "未知状态"
-	}
+	return GetTemplateStatusWithLocale(LocaleZhCN, status)
 }
 
 // GetTemplateAddTime 将UNIX时间戳转换为时间
@@ -1289,14 +1939,19 @@ func GetTemplateAddTime(timestamp int64) time.Time {
 
 // SMSReports 短信分析报告
 func (c *Client) SMSReports(req *SMSReportsRequest) (*SMSReportsResponse, error) {
-	body, err := c.doJSONRequest("POST", EndpointSMSReports, req)
+	return c.SMSReportsCtx(context.Background(), req)
+}
+
+// SMSReportsCtx 短信分析报告（支持 context 取消与超时控制）
+func (c *Client) SMSReportsCtx(ctx context.Context, req *SMSReportsRequest) (*SMSReportsResponse, error) {
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSReports, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSReportsResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信分析报告响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSReports, body, err)
 	}
 
 	return &resp, nil
@@ -1304,40 +1959,60 @@ func (c *Client) SMSReports(req *SMSReportsRequest) (*SMSReportsResponse, error)
 
 // SMSReportsWithDateRange 使用日期范围查询短信分析报告（便捷方法）
 func (c *Client) SMSReportsWithDateRange(startDate, endDate time.Time) (*SMSReportsResponse, error) {
+	return c.SMSReportsWithDateRangeCtx(context.Background(), startDate, endDate)
+}
+
+// SMSReportsWithDateRangeCtx 使用日期范围查询短信分析报告（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSReportsWithDateRangeCtx(ctx context.Context, startDate, endDate time.Time) (*SMSReportsResponse, error) {
 	req := &SMSReportsRequest{
 		StartDate: startDate.Unix(),
 		EndDate:   endDate.Unix(),
 	}
 
-	return c.SMSReports(req)
+	return c.SMSReportsCtx(ctx, req)
 }
 
 // SMSReportsLast7Days 获取最近7天的短信分析报告（便捷方法）
 func (c *Client) SMSReportsLast7Days() (*SMSReportsResponse, error) {
+	return c.SMSReportsLast7DaysCtx(context.Background())
+}
+
+// SMSReportsLast7DaysCtx 获取最近7天的短信分析报告（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSReportsLast7DaysCtx(ctx context.Context) (*SMSReportsResponse, error) {
 	now := time.Now()
 	startDate := now.AddDate(0, 0, -7) // 7天前
 
-	return c.SMSReportsWithDateRange(startDate, now)
+	return c.SMSReportsWithDateRangeCtx(ctx, startDate, now)
 }
 
 // SMSReportsLastMonth 获取上个月的短信分析报告（便捷方法）
 func (c *Client) SMSReportsLastMonth() (*SMSReportsResponse, error) {
+	return c.SMSReportsLastMonthCtx(context.Background())
+}
+
+// SMSReportsLastMonthCtx 获取上个月的短信分析报告（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSReportsLastMonthCtx(ctx context.Context) (*SMSReportsResponse, error) {
 	now := time.Now()
 	startDate := now.AddDate(0, -1, 0) // 1个月前
 
-	return c.SMSReportsWithDateRange(startDate, now)
+	return c.SMSReportsWithDateRangeCtx(ctx, startDate, now)
 }
 
 // SMSBalance 短信余额查询
 func (c *Client) SMSBalance() (*SMSBalanceResponse, error) {
-	body, err := c.doJSONRequest("POST", EndpointSMSBalance, nil)
+	return c.SMSBalanceCtx(context.Background())
+}
+
+// SMSBalanceCtx 短信余额查询（支持 context 取消与超时控制）
+func (c *Client) SMSBalanceCtx(ctx context.Context) (*SMSBalanceResponse, error) {
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSBalance, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSBalanceResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信余额响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSBalance, body, err)
 	}
 
 	return &resp, nil
@@ -1345,14 +2020,19 @@ func (c *Client) SMSBalance() (*SMSBalanceResponse, error) {
 
 // SMSBalanceLog 短信余额日志查询
 func (c *Client) SMSBalanceLog(req *SMSBalanceLogRequest) (*SMSBalanceLogResponse, error) {
-	body, err := c.doJSONRequest("POST", EndpointSMSBalanceLog, req)
+	return c.SMSBalanceLogCtx(context.Background(), req)
+}
+
+// SMSBalanceLogCtx 短信余额日志查询（支持 context 取消与超时控制）
+func (c *Client) SMSBalanceLogCtx(ctx context.Context, req *SMSBalanceLogRequest) (*SMSBalanceLogResponse, error) {
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSBalanceLog, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSBalanceLogResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信余额日志响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSBalanceLog, body, err)
 	}
 
 	return &resp, nil
@@ -1360,40 +2040,60 @@ func (c *Client) SMSBalanceLog(req *SMSBalanceLogRequest) (*SMSBalanceLogRespons
 
 // SMSBalanceLogWithDateRange 使用日期范围查询短信余额日志（便捷方法）
 func (c *Client) SMSBalanceLogWithDateRange(startDate, endDate time.Time) (*SMSBalanceLogResponse, error) {
+	return c.SMSBalanceLogWithDateRangeCtx(context.Background(), startDate, endDate)
+}
+
+// SMSBalanceLogWithDateRangeCtx 使用日期范围查询短信余额日志（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSBalanceLogWithDateRangeCtx(ctx context.Context, startDate, endDate time.Time) (*SMSBalanceLogResponse, error) {
 	req := &SMSBalanceLogRequest{
 		StartDate: startDate.Unix(),
 		EndDate:   endDate.Unix(),
 	}
 
-	return c.SMSBalanceLog(req)
+	return c.SMSBalanceLogCtx(ctx, req)
 }
 
 // SMSBalanceLogLast7Days 获取最近7天的短信余额日志（便捷方法）
 func (c *Client) SMSBalanceLogLast7Days() (*SMSBalanceLogResponse, error) {
+	return c.SMSBalanceLogLast7DaysCtx(context.Background())
+}
+
+// SMSBalanceLogLast7DaysCtx 获取最近7天的短信余额日志（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSBalanceLogLast7DaysCtx(ctx context.Context) (*SMSBalanceLogResponse, error) {
 	now := time.Now()
 	startDate := now.AddDate(0, 0, -7) // 7天前
 
-	return c.SMSBalanceLogWithDateRange(startDate, now)
+	return c.SMSBalanceLogWithDateRangeCtx(ctx, startDate, now)
 }
 
 // SMSBalanceLogLastMonth 获取上个月的短信余额日志（便捷方法）
 func (c *Client) SMSBalanceLogLastMonth() (*SMSBalanceLogResponse, error) {
+	return c.SMSBalanceLogLastMonthCtx(context.Background())
+}
+
+// SMSBalanceLogLastMonthCtx 获取上个月的短信余额日志（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSBalanceLogLastMonthCtx(ctx context.Context) (*SMSBalanceLogResponse, error) {
 	now := time.Now()
 	startDate := now.AddDate(0, -1, 0) // 1个月前
 
-	return c.SMSBalanceLogWithDateRange(startDate, now)
+	return c.SMSBalanceLogWithDateRangeCtx(ctx, startDate, now)
 }
 
 // SMSLog 短信历史明细查询
 func (c *Client) SMSLog(req *SMSLogRequest) (*SMSLogResponse, error) {
-	body, err := c.doJSONRequestWithBaseURL("POST", EndpointSMSLog, req, LogBaseURL)
+	return c.SMSLogCtx(context.Background(), req)
+}
+
+// SMSLogCtx 短信历史明细查询（支持 context 取消与超时控制）
+func (c *Client) SMSLogCtx(ctx context.Context, req *SMSLogRequest) (*SMSLogResponse, error) {
+	body, err := c.doJSONRequestWithPool(ctx, "POST", EndpointSMSLog, req, c.logPool)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSLogResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信历史明细响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSLog, body, err)
 	}
 
 	return &resp, nil
@@ -1401,63 +2101,93 @@ func (c *Client) SMSLog(req *SMSLogRequest) (*SMSLogResponse, error) {
 
 // SMSLogWithDateRange 使用日期范围查询短信历史明细（便捷方法）
 func (c *Client) SMSLogWithDateRange(startDate, endDate time.Time) (*SMSLogResponse, error) {
+	return c.SMSLogWithDateRangeCtx(context.Background(), startDate, endDate)
+}
+
+// SMSLogWithDateRangeCtx 使用日期范围查询短信历史明细（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSLogWithDateRangeCtx(ctx context.Context, startDate, endDate time.Time) (*SMSLogResponse, error) {
 	req := &SMSLogRequest{
 		StartDate: startDate.Unix(),
 		EndDate:   endDate.Unix(),
 		Rows:      50, // 默认返回50条
 	}
 
-	return c.SMSLog(req)
+	return c.SMSLogCtx(ctx, req)
 }
 
 // SMSLogLast7Days 获取最近7天的短信历史明细（便捷方法）
 func (c *Client) SMSLogLast7Days() (*SMSLogResponse, error) {
+	return c.SMSLogLast7DaysCtx(context.Background())
+}
+
+// SMSLogLast7DaysCtx 获取最近7天的短信历史明细（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSLogLast7DaysCtx(ctx context.Context) (*SMSLogResponse, error) {
 	now := time.Now()
 	startDate := now.AddDate(0, 0, -7) // 7天前
 
-	return c.SMSLogWithDateRange(startDate, now)
+	return c.SMSLogWithDateRangeCtx(ctx, startDate, now)
 }
 
 // SMSLogByPhone 根据手机号查询短信历史明细（便捷方法）
 func (c *Client) SMSLogByPhone(phone string) (*SMSLogResponse, error) {
+	return c.SMSLogByPhoneCtx(context.Background(), phone)
+}
+
+// SMSLogByPhoneCtx 根据手机号查询短信历史明细（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSLogByPhoneCtx(ctx context.Context, phone string) (*SMSLogResponse, error) {
 	req := &SMSLogRequest{
 		To:   phone,
 		Rows: 50,
 	}
 
-	return c.SMSLog(req)
+	return c.SMSLogCtx(ctx, req)
 }
 
 // SMSLogBySendID 根据Send ID查询短信历史明细（便捷方法）
 func (c *Client) SMSLogBySendID(sendID string) (*SMSLogResponse, error) {
+	return c.SMSLogBySendIDCtx(context.Background(), sendID)
+}
+
+// SMSLogBySendIDCtx 根据Send ID查询短信历史明细（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSLogBySendIDCtx(ctx context.Context, sendID string) (*SMSLogResponse, error) {
 	req := &SMSLogRequest{
 		SendID: sendID,
 		Rows:   50,
 	}
 
-	return c.SMSLog(req)
+	return c.SMSLogCtx(ctx, req)
 }
 
 // SMSLogByStatus 根据状态查询短信历史明细（便捷方法）
 func (c *Client) SMSLogByStatus(status string) (*SMSLogResponse, error) {
+	return c.SMSLogByStatusCtx(context.Background(), status)
+}
+
+// SMSLogByStatusCtx 根据状态查询短信历史明细（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSLogByStatusCtx(ctx context.Context, status string) (*SMSLogResponse, error) {
 	req := &SMSLogRequest{
 		Status: status, // "delivered" 或 "dropped"
 		Rows:   50,
 	}
 
-	return c.SMSLog(req)
+	return c.SMSLogCtx(ctx, req)
 }
 
 // SMSMO 短信上行查询
 func (c *Client) SMSMO(req *SMSMORequest) (*SMSMOResponse, error) {
-	body, err := c.doJSONRequest("POST", EndpointSMSMO, req)
+	return c.SMSMOCtx(context.Background(), req)
+}
+
+// SMSMOCtx 短信上行查询（支持 context 取消与超时控制）
+func (c *Client) SMSMOCtx(ctx context.Context, req *SMSMORequest) (*SMSMOResponse, error) {
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSMSMO, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SMSMOResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析短信上行查询响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSMSMO, body, err)
 	}
 
 	return &resp, nil
@@ -1465,31 +2195,46 @@ func (c *Client) SMSMO(req *SMSMORequest) (*SMSMOResponse, error) {
 
 // SMSMOWithDateRange 使用日期范围查询短信上行（便捷方法）
 func (c *Client) SMSMOWithDateRange(startDate, endDate time.Time) (*SMSMOResponse, error) {
+	return c.SMSMOWithDateRangeCtx(context.Background(), startDate, endDate)
+}
+
+// SMSMOWithDateRangeCtx 使用日期范围查询短信上行（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSMOWithDateRangeCtx(ctx context.Context, startDate, endDate time.Time) (*SMSMOResponse, error) {
 	req := &SMSMORequest{
 		StartDate: startDate.Unix(),
 		EndDate:   endDate.Unix(),
 		Rows:      50, // 默认返回50条
 	}
 
-	return c.SMSMO(req)
+	return c.SMSMOCtx(ctx, req)
 }
 
 // SMSMOLast7Days 获取最近7天的短信上行（便捷方法）
 func (c *Client) SMSMOLast7Days() (*SMSMOResponse, error) {
+	return c.SMSMOLast7DaysCtx(context.Background())
+}
+
+// SMSMOLast7DaysCtx 获取最近7天的短信上行（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSMOLast7DaysCtx(ctx context.Context) (*SMSMOResponse, error) {
 	now := time.Now()
 	startDate := now.AddDate(0, 0, -7) // 7天前
 
-	return c.SMSMOWithDateRange(startDate, now)
+	return c.SMSMOWithDateRangeCtx(ctx, startDate, now)
 }
 
 // SMSMOByPhone 根据手机号查询短信上行（便捷方法）
 func (c *Client) SMSMOByPhone(phone string) (*SMSMOResponse, error) {
+	return c.SMSMOByPhoneCtx(context.Background(), phone)
+}
+
+// SMSMOByPhoneCtx 根据手机号查询短信上行（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SMSMOByPhoneCtx(ctx context.Context, phone string) (*SMSMOResponse, error) {
 	req := &SMSMORequest{
 		From: phone,
 		Rows: 50,
 	}
 
-	return c.SMSMO(req)
+	return c.SMSMOCtx(ctx, req)
 }
 
 // ===== 分析报告数据处理方法 =====
@@ -1988,31 +2733,36 @@ func (resp *SMSMOResponse) GetReplyRate() map[string]float64 {
 
 // SubhookCreate 创建 SUBHOOK
 func (c *Client) SubhookCreate(req *SubhookCreateRequest) (*SubhookCreateResponse, error) {
+	return c.SubhookCreateCtx(context.Background(), req)
+}
+
+// SubhookCreateCtx 创建 SUBHOOK（支持 context 取消与超时控制）
+func (c *Client) SubhookCreateCtx(ctx context.Context, req *SubhookCreateRequest) (*SubhookCreateResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
 	// 验证必填参数
 	if req.URL == "" {
-		return nil, fmt.Errorf("回调URL不能为空")
+		return nil, c.validationError("url", msgSubhookURLRequired)
 	}
 	if len(req.Event) == 0 {
-		return nil, fmt.Errorf("事件类型不能为空")
+		return nil, c.validationError("event", msgSubhookEventRequired)
 	}
 
 	// 验证事件类型
-	if errors := ValidateEventTypes(req.Event); len(errors) > 0 {
-		return nil, fmt.Errorf("事件类型验证失败: %v", errors)
+	if errors := validateEventTypes(c.locale, req.Event); len(errors) > 0 {
+		return nil, c.validationError("event", msgInvalidEventTypes, errors)
 	}
 
-	body, err := c.doJSONRequest("POST", EndpointSubhook, req)
+	body, err := c.doJSONRequest(ctx, "POST", EndpointSubhook, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SubhookCreateResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析 SUBHOOK 创建响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSubhookCreate, body, err)
 	}
 
 	return &resp, nil
@@ -2020,14 +2770,19 @@ func (c *Client) SubhookCreate(req *SubhookCreateRequest) (*SubhookCreateRespons
 
 // SubhookQuery 查询 SUBHOOK
 func (c *Client) SubhookQuery(req *SubhookQueryRequest) (*SubhookQueryResponse, error) {
-	body, err := c.doJSONRequest("GET", EndpointSubhook, req)
+	return c.SubhookQueryCtx(context.Background(), req)
+}
+
+// SubhookQueryCtx 查询 SUBHOOK（支持 context 取消与超时控制）
+func (c *Client) SubhookQueryCtx(ctx context.Context, req *SubhookQueryRequest) (*SubhookQueryResponse, error) {
+	body, err := c.doJSONRequest(ctx, "GET", EndpointSubhook, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SubhookQueryResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析 SUBHOOK 查询响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSubhookQuery, body, err)
 	}
 
 	return &resp, nil
@@ -2035,23 +2790,28 @@ func (c *Client) SubhookQuery(req *SubhookQueryRequest) (*SubhookQueryResponse,
 
 // SubhookDelete 删除 SUBHOOK
 func (c *Client) SubhookDelete(req *SubhookDeleteRequest) (*SubhookDeleteResponse, error) {
+	return c.SubhookDeleteCtx(context.Background(), req)
+}
+
+// SubhookDeleteCtx 删除 SUBHOOK（支持 context 取消与超时控制）
+func (c *Client) SubhookDeleteCtx(ctx context.Context, req *SubhookDeleteRequest) (*SubhookDeleteResponse, error) {
 	if req == nil {
-		return nil, fmt.Errorf("请求参数不能为空")
+		return nil, c.validationError("req", msgRequestNil)
 	}
 
 	if req.Target == "" {
-		return nil, fmt.Errorf("SUBHOOK ID不能为空")
+		return nil, c.validationError("target", msgSubhookIDRequired)
 	}
 
 	// 根据SUBMAIL文档，DELETE 请求参数应该放在请求体中
-	body, err := c.doJSONRequest("DELETE", EndpointSubhook, req)
+	body, err := c.doJSONRequest(ctx, "DELETE", EndpointSubhook, req)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp SubhookDeleteResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("解析 SUBHOOK 删除响应失败: %v", err)
+	if err := c.decoder.Decode(body, &resp); err != nil {
+		return nil, c.decodeError(targetSubhookDelete, body, err)
 	}
 
 	return &resp, nil
@@ -2061,42 +2821,67 @@ func (c *Client) SubhookDelete(req *SubhookDeleteRequest) (*SubhookDeleteRespons
 
 // SubhookCreateWithEvents 创建指定事件的 SUBHOOK（便捷方法）
 func (c *Client) SubhookCreateWithEvents(url string, events []string, tag string) (*SubhookCreateResponse, error) {
+	return c.SubhookCreateWithEventsCtx(context.Background(), url, events, tag)
+}
+
+// SubhookCreateWithEventsCtx 创建指定事件的 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookCreateWithEventsCtx(ctx context.Context, url string, events []string, tag string) (*SubhookCreateResponse, error) {
 	req := &SubhookCreateRequest{
 		URL:   url,
 		Event: events,
 		Tag:   tag,
 	}
-	return c.SubhookCreate(req)
+	return c.SubhookCreateCtx(ctx, req)
 }
 
 // SubhookCreateForSMS 创建短信相关事件的 SUBHOOK（便捷方法）
 func (c *Client) SubhookCreateForSMS(url string, tag string) (*SubhookCreateResponse, error) {
+	return c.SubhookCreateForSMSCtx(context.Background(), url, tag)
+}
+
+// SubhookCreateForSMSCtx 创建短信相关事件的 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookCreateForSMSCtx(ctx context.Context, url string, tag string) (*SubhookCreateResponse, error) {
 	events := []string{
 		SubhookEventRequest,
 		SubhookEventDelivered,
 		SubhookEventDropped,
 		SubhookEventSending,
 	}
-	return c.SubhookCreateWithEvents(url, events, tag)
+	return c.SubhookCreateWithEventsCtx(ctx, url, events, tag)
 }
 
 // SubhookCreateForTemplate 创建模板审核相关事件的 SUBHOOK（便捷方法）
 func (c *Client) SubhookCreateForTemplate(url string, tag string) (*SubhookCreateResponse, error) {
+	return c.SubhookCreateForTemplateCtx(context.Background(), url, tag)
+}
+
+// SubhookCreateForTemplateCtx 创建模板审核相关事件的 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookCreateForTemplateCtx(ctx context.Context, url string, tag string) (*SubhookCreateResponse, error) {
 	events := []string{
 		SubhookEventTemplateAccept,
 		SubhookEventTemplateReject,
 	}
-	return c.SubhookCreateWithEvents(url, events, tag)
+	return c.SubhookCreateWithEventsCtx(ctx, url, events, tag)
 }
 
 // SubhookCreateForMO 创建短信上行事件的 SUBHOOK（便捷方法）
 func (c *Client) SubhookCreateForMO(url string, tag string) (*SubhookCreateResponse, error) {
+	return c.SubhookCreateForMOCtx(context.Background(), url, tag)
+}
+
+// SubhookCreateForMOCtx 创建短信上行事件的 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookCreateForMOCtx(ctx context.Context, url string, tag string) (*SubhookCreateResponse, error) {
 	events := []string{SubhookEventMO}
-	return c.SubhookCreateWithEvents(url, events, tag)
+	return c.SubhookCreateWithEventsCtx(ctx, url, events, tag)
 }
 
 // SubhookCreateForAll 创建所有事件的 SUBHOOK（便捷方法）
 func (c *Client) SubhookCreateForAll(url string, tag string) (*SubhookCreateResponse, error) {
+	return c.SubhookCreateForAllCtx(context.Background(), url, tag)
+}
+
+// SubhookCreateForAllCtx 创建所有事件的 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookCreateForAllCtx(ctx context.Context, url string, tag string) (*SubhookCreateResponse, error) {
 	events := []string{
 		SubhookEventRequest,
 		SubhookEventDelivered,
@@ -2106,25 +2891,40 @@ func (c *Client) SubhookCreateForAll(url string, tag string) (*SubhookCreateResp
 		SubhookEventTemplateAccept,
 		SubhookEventTemplateReject,
 	}
-	return c.SubhookCreateWithEvents(url, events, tag)
+	return c.SubhookCreateWithEventsCtx(ctx, url, events, tag)
 }
 
 // SubhookQueryAll 查询所有 SUBHOOK（便捷方法）
 func (c *Client) SubhookQueryAll() (*SubhookQueryResponse, error) {
+	return c.SubhookQueryAllCtx(context.Background())
+}
+
+// SubhookQueryAllCtx 查询所有 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookQueryAllCtx(ctx context.Context) (*SubhookQueryResponse, error) {
 	req := &SubhookQueryRequest{}
-	return c.SubhookQuery(req)
+	return c.SubhookQueryCtx(ctx, req)
 }
 
 // SubhookQueryByID 根据ID查询 SUBHOOK（便捷方法）
 func (c *Client) SubhookQueryByID(target string) (*SubhookQueryResponse, error) {
+	return c.SubhookQueryByIDCtx(context.Background(), target)
+}
+
+// SubhookQueryByIDCtx 根据ID查询 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookQueryByIDCtx(ctx context.Context, target string) (*SubhookQueryResponse, error) {
 	req := &SubhookQueryRequest{Target: target}
-	return c.SubhookQuery(req)
+	return c.SubhookQueryCtx(ctx, req)
 }
 
 // SubhookDeleteByID 根据ID删除 SUBHOOK（便捷方法）
 func (c *Client) SubhookDeleteByID(target string) (*SubhookDeleteResponse, error) {
+	return c.SubhookDeleteByIDCtx(context.Background(), target)
+}
+
+// SubhookDeleteByIDCtx 根据ID删除 SUBHOOK（便捷方法，支持 context 取消与超时控制）
+func (c *Client) SubhookDeleteByIDCtx(ctx context.Context, target string) (*SubhookDeleteResponse, error) {
 	req := &SubhookDeleteRequest{Target: target}
-	return c.SubhookDelete(req)
+	return c.SubhookDeleteCtx(ctx, req)
 }
 
 // GetSubhookHandler 获取 SUBHOOK 处理器
@@ -0,0 +1,68 @@
+package submail_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestSMSSendCtxSendsMessage(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	resp, err := client.SMSSendCtx(context.Background(), &submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"})
+	if err != nil {
+		t.Fatalf("SMSSendCtx: %v", err)
+	}
+	if resp.Status != "success" || resp.SendID == "" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if got := server.MessagesTo("13800138000"); len(got) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(got))
+	}
+}
+
+func TestSMSSendCtxCanceledBeforeSend(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.SMSSendCtx(ctx, &submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if n := len(server.Messages()); n != 0 {
+		t.Fatalf("server received %d messages, want 0", n)
+	}
+}
+
+func TestServiceStatusCtxDeadline(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer slow.Close()
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", BaseURL: slow.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.ServiceStatusCtx(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("request took %v, deadline was not honored", elapsed)
+	}
+}
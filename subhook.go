@@ -317,20 +317,7 @@ func CreateSubhookHTTPHandler(subhookKey string, handler SubhookEventHandler) ht
 
 // GetEventTypeDescription 获取事件类型描述
 func GetEventTypeDescription(eventType string) string {
-	descriptions := map[string]string{
-		SubhookEventRequest:        "发送请求被接收",
-		SubhookEventDelivered:      "发送成功",
-		SubhookEventDropped:        "发送失败",
-		SubhookEventSending:        "正在发送",
-		SubhookEventMO:             "短信上行（用户回复）",
-		SubhookEventTemplateAccept: "短信模板审核通过",
-		SubhookEventTemplateReject: "短信模板审核未通过",
-	}
-
-	if desc, exists := descriptions[eventType]; exists {
-		return desc
-	}
-	return "未知事件类型"
+	return GetEventTypeDescriptionWithLocale(LocaleZhCN, eventType)
 }
 
 // IsValidEventType 检查事件类型是否有效
@@ -355,10 +342,15 @@ func IsValidEventType(eventType string) bool {
 
 // ValidateEventTypes 验证事件类型数组
 func ValidateEventTypes(eventTypes []string) []string {
+	return validateEventTypes(LocaleZhCN, eventTypes)
+}
+
+// validateEventTypes 使用指定语言验证事件类型数组
+func validateEventTypes(locale string, eventTypes []string) []string {
 	var errors []string
 	for _, eventType := range eventTypes {
 		if !IsValidEventType(eventType) {
-			errors = append(errors, fmt.Sprintf("无效的事件类型: %s", eventType))
+			errors = append(errors, localizef(locale, msgInvalidEventType, eventType))
 		}
 	}
 	return errors
@@ -0,0 +1,279 @@
+package submailtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 录制回放模式
+const (
+	CassetteRecord = "record" // 录制：请求真实服务器并将请求/响应写入文件
+	CassetteReplay = "replay" // 回放：从文件中匹配请求并返回录制的响应，不访问网络
+	CassetteAuto   = "auto"   // 自动：文件存在时回放，否则录制
+)
+
+// redacted 脱敏后的占位值
+const redacted = "[REDACTED]"
+
+// redactedParams 录制时脱敏且不参与匹配的认证参数
+var redactedParams = []string{"appid", "signature", "timestamp"}
+
+// authParams 不参与匹配的认证参数（明文与数字签名模式录制的请求可以互相回放）
+var authParams = map[string]bool{"appid": true, "signature": true, "timestamp": true, "sign_type": true}
+
+// Interaction 一次录制的请求/响应
+type Interaction struct {
+	Method      string            `json:"method"`       // HTTP方法
+	Endpoint    string            `json:"endpoint"`     // 请求路径（含 .json/.xml 后缀，不含基础URL）
+	Params      map[string]string `json:"params"`       // 请求参数（认证参数已脱敏）
+	Status      int               `json:"status"`       // HTTP状态码
+	ContentType string            `json:"content_type"` // 响应 Content-Type
+	Body        string            `json:"body"`         // 响应内容（AppID 已脱敏）
+}
+
+// cassetteFile 录制文件格式
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette 录制回放传输层，通过 Config.Transport 使用
+//
+//	cassette, err := submailtest.NewCassette("testdata/sms_log.json", submailtest.CassetteAuto)
+//	client := submail.NewClient(submail.Config{AppID: appID, AppKey: appKey, Transport: cassette})
+//
+// 回放时按 HTTP 方法、请求路径及规范化后的参数（忽略认证参数与 IgnoreParams）匹配录制的请求，
+// 相同的请求按录制顺序依次返回，全部用完后重复返回最后一个
+type Cassette struct {
+	Path string // 录制文件路径
+	Mode string // 录制回放模式
+
+	// Transport 录制时使用的底层传输层（默认 http.DefaultTransport）
+	Transport http.RoundTripper
+
+	// IgnoreParams 不参与匹配的参数（如 SMSReportsLast7Days 中随当前时间变化的 start_date、end_date）
+	IgnoreParams []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewCassette 创建录制回放传输层，回放模式下加载录制文件
+func NewCassette(path, mode string) (*Cassette, error) {
+	if mode == CassetteAuto {
+		mode = CassetteRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = CassetteReplay
+		}
+	}
+	if mode != CassetteRecord && mode != CassetteReplay {
+		return nil, localizedError(msgCassetteMode, mode)
+	}
+
+	c := &Cassette{Path: path, Mode: mode}
+	if mode == CassetteReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, localizedError(msgCassetteRead, err)
+		}
+		var file cassetteFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, localizedError(msgCassetteParse, err)
+		}
+		c.interactions = file.Interactions
+		c.used = make([]bool, len(file.Interactions))
+	}
+	return c, nil
+}
+
+// Interactions 获取已录制（或已加载）的请求/响应
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	parsed := req.Clone(req.Context())
+	parsed.Body = io.NopCloser(bytes.NewReader(body))
+	params, _, err := parseParams(parsed)
+	if err != nil {
+		return nil, localizedError(msgCassetteParams, err)
+	}
+
+	if c.Mode == CassetteReplay {
+		return c.replay(req, params)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return c.record(req, params)
+}
+
+// record 请求真实服务器并录制
+func (c *Cassette) record(req *http.Request, params map[string]string) (*http.Response, error) {
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := make(map[string]string, len(params))
+	for k, v := range params {
+		recorded[k] = v
+	}
+	for _, k := range redactedParams {
+		if _, ok := recorded[k]; ok {
+			recorded[k] = redacted
+		}
+	}
+
+	// 只替换完整的取值（JSON 字符串或 XML 元素内容），避免误伤包含相同字符的键名
+	responseBody := string(body)
+	if appID := params["appid"]; appID != "" {
+		responseBody = strings.NewReplacer(
+			`"`+appID+`"`, `"`+redacted+`"`,
+			">"+appID+"<", ">"+redacted+"<",
+		).Replace(responseBody)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, Interaction{
+		Method:      req.Method,
+		Endpoint:    req.URL.Path,
+		Params:      recorded,
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        responseBody,
+	})
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save 写入录制文件
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return localizedError(msgCassetteMarshal, err)
+	}
+	if dir := filepath.Dir(c.Path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return localizedError(msgCassetteDir, err)
+		}
+	}
+	if err := os.WriteFile(c.Path, data, 0o644); err != nil {
+		return localizedError(msgCassetteWrite, err)
+	}
+	return nil
+}
+
+// replay 返回匹配的录制响应
+func (c *Cassette) replay(req *http.Request, params map[string]string) (*http.Response, error) {
+	key := c.matchKey(params)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match := -1
+	for i, it := range c.interactions {
+		if it.Method != req.Method || it.Endpoint != req.URL.Path || c.matchKey(it.Params) != key {
+			continue
+		}
+		match = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, localizedError(msgCassetteNoMatch, req.Method, req.URL.Path, key)
+	}
+	c.used[match] = true
+
+	it := c.interactions[match]
+	header := make(http.Header)
+	if it.ContentType != "" {
+		header.Set("Content-Type", it.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Status, http.StatusText(it.Status)),
+		StatusCode:    it.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(it.Body)),
+		ContentLength: int64(len(it.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matchKey 规范化参数用于匹配：忽略认证参数与 IgnoreParams，JSON 参数重新序列化以消除键顺序差异
+func (c *Cassette) matchKey(params map[string]string) string {
+	ignored := make(map[string]bool, len(c.IgnoreParams))
+	for _, k := range c.IgnoreParams {
+		ignored[k] = true
+	}
+
+	var keys []string
+	for k := range params {
+		if !authParams[k] && !ignored[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+normalizeJSON(params[k]))
+	}
+	return strings.Join(parts, "&")
+}
+
+// normalizeJSON 将 JSON 对象或数组重新序列化（json.Marshal 按键名排序），其他值原样返回
+func normalizeJSON(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return value
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+		return value
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return value
+	}
+	return string(data)
+}
@@ -0,0 +1,74 @@
+package submailtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "send.json")
+	server := submailtest.NewServer("test-app", "test-key")
+
+	recorder, err := submailtest.NewCassette(path, submailtest.CassetteAuto)
+	if err != nil {
+		t.Fatalf("NewCassette: %v", err)
+	}
+	if recorder.Mode != submailtest.CassetteRecord {
+		t.Fatalf("mode = %q, want record for a missing file", recorder.Mode)
+	}
+
+	config := server.Config()
+	config.Transport = recorder
+	req := &submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}
+	recorded, err := submail.NewClient(config).SMSSend(req)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cassette not written: %v", err)
+	}
+	if strings.Contains(string(data), "test-key") || strings.Contains(string(data), "test-app") {
+		t.Fatalf("cassette leaks credentials: %s", data)
+	}
+
+	// 回放不访问网络，认证参数不参与匹配
+	replayer, err := submailtest.NewCassette(path, submailtest.CassetteAuto)
+	if err != nil {
+		t.Fatalf("NewCassette: %v", err)
+	}
+	if replayer.Mode != submailtest.CassetteReplay {
+		t.Fatalf("mode = %q, want replay for an existing file", replayer.Mode)
+	}
+	config.Transport = replayer
+	config.AppKey = "other-key"
+	client := submail.NewClient(config)
+
+	replayed, err := client.SMSSend(req)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed.SendID != recorded.SendID {
+		t.Fatalf("replayed send_id = %q, want %q", replayed.SendID, recorded.SendID)
+	}
+
+	if _, err := client.SMSSend(&submail.SMSSendRequest{To: "13800138001", Content: "【测试】验证码1234"}); err == nil {
+		t.Fatal("unrecorded request replayed successfully")
+	}
+}
+
+func TestCassetteInvalidMode(t *testing.T) {
+	if _, err := submailtest.NewCassette(filepath.Join(t.TempDir(), "c.json"), "rewind"); err == nil {
+		t.Fatal("NewCassette accepted an unknown mode")
+	}
+	if _, err := submailtest.NewCassette(filepath.Join(t.TempDir(), "missing.json"), submailtest.CassetteReplay); err == nil {
+		t.Fatal("NewCassette replayed a missing file")
+	}
+}
@@ -0,0 +1,798 @@
+package submailtest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+)
+
+// call 一次已解析的API请求
+type call struct {
+	method   string
+	endpoint string
+	params   map[string]string
+}
+
+// handler 接口处理函数，返回响应数据；code 非0时返回对应的API错误
+type handler func(s *Server, c *call) (result interface{}, code int)
+
+// handlers 接口路由表
+var handlers = map[string]handler{
+	submail.EndpointServiceTimestamp: (*Server).handleTimestamp,
+	submail.EndpointServiceStatus:    (*Server).handleStatus,
+	submail.EndpointSMSSend:          (*Server).handleSend,
+	submail.EndpointSMSXSend:         (*Server).handleXSend,
+	submail.EndpointSMSMultiSend:     (*Server).handleMultiSend,
+	submail.EndpointSMSMultiXSend:    (*Server).handleMultiXSend,
+	submail.EndpointSMSBatchSend:     (*Server).handleBatchSend,
+	submail.EndpointSMSBatchXSend:    (*Server).handleBatchXSend,
+	submail.EndpointSMSUnionSend:     (*Server).handleUnionSend,
+	submail.EndpointSMSTemplate:      (*Server).handleTemplate,
+	submail.EndpointSMSReports:       (*Server).handleReports,
+	submail.EndpointSMSBalance:       (*Server).handleBalance,
+	submail.EndpointSMSBalanceLog:    (*Server).handleBalanceLog,
+	submail.EndpointSMSLog:           (*Server).handleLog,
+	submail.EndpointSMSMO:            (*Server).handleMO,
+	submail.EndpointSMSAppextend:     (*Server).handleAppextend,
+	submail.EndpointSubhook:          (*Server).handleSubhook,
+}
+
+// varPattern 模板变量 @var(key)
+var varPattern = regexp.MustCompile(`@var\(([^)]+)\)`)
+
+// ServeHTTP 实现 http.Handler 接口
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint, format := splitFormat(r.URL.Path)
+
+	params, files, err := parseParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method:   r.Method,
+		Endpoint: endpoint,
+		Format:   format,
+		Params:   params,
+		Files:    files,
+	})
+
+	h, ok := handlers[endpoint]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if f, ok := s.takeFailure(endpoint); ok {
+		if f.status != 0 {
+			http.Error(w, http.StatusText(f.status), f.status)
+			return
+		}
+		writeResponse(w, format, errorResponse(f.code))
+		return
+	}
+
+	// 获取时间戳接口不需要认证
+	if endpoint != submail.EndpointServiceTimestamp {
+		if code := s.authenticate(params); code != 0 {
+			writeResponse(w, format, errorResponse(code))
+			return
+		}
+	}
+
+	result, code := h(s, &call{method: r.Method, endpoint: endpoint, params: params})
+	if code != 0 {
+		result = errorResponse(code)
+	}
+	writeResponse(w, format, result)
+}
+
+// splitFormat 拆分请求路径中的 .json/.xml 后缀
+func splitFormat(path string) (endpoint, format string) {
+	for _, f := range []string{submail.FormatJSON, submail.FormatXML} {
+		if strings.HasSuffix(path, "."+f) {
+			return strings.TrimSuffix(path, "."+f), f
+		}
+	}
+	return path, submail.FormatJSON
+}
+
+// parseParams 解析查询参数、表单或 multipart 请求体
+// 标准库只解析 POST/PUT/PATCH 请求体，DELETE 请求体需要单独处理
+func parseParams(r *http.Request) (map[string]string, int, error) {
+	params := make(map[string]string)
+	for k, v := range r.URL.Query() {
+		params[k] = v[0]
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, 0, err
+		}
+		for k, v := range r.MultipartForm.Value {
+			params[k] = v[0]
+		}
+		files := 0
+		for _, fhs := range r.MultipartForm.File {
+			files += len(fhs)
+		}
+		return params, files, nil
+	case "application/x-www-form-urlencoded":
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, 0, err
+		}
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, 0, err
+		}
+		for k, v := range values {
+			params[k] = v[0]
+		}
+	}
+	return params, 0, nil
+}
+
+// ===== 认证 =====
+
+// authenticate 按照客户端 buildSignature 的规则校验认证参数，返回错误码（0 表示通过）
+func (s *Server) authenticate(params map[string]string) int {
+	if params["appid"] != s.AppID {
+		return submail.ErrIncorrectAppID
+	}
+
+	signature := params["signature"]
+	if signature == "" {
+		return submail.ErrEmptySignatureParam
+	}
+
+	signType := params["sign_type"]
+	if signType == "" || signType == "normal" {
+		if signature != s.AppKey {
+			return submail.ErrInvalidAppKey
+		}
+		return 0
+	}
+	if signType != submail.SignTypeMD5 && signType != submail.SignTypeSHA1 {
+		return submail.ErrInvalidSignType
+	}
+
+	timestamp, err := strconv.ParseInt(params["timestamp"], 10, 64)
+	if err != nil || timestamp <= 0 {
+		return submail.ErrTimestampError
+	}
+	if s.TimestampTolerance >= 0 {
+		skew := s.Now().Sub(time.Unix(timestamp, 0))
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > s.TimestampTolerance {
+			return submail.ErrInvalidTimestamp
+		}
+	}
+
+	if signature != s.sign(signType, params) {
+		return submail.ErrInvalidSignature
+	}
+	return 0
+}
+
+// sign 计算数字签名：除 signature、tag、sms_signature 外的参数按键名排序拼接，
+// 前后各加上 AppID+AppKey 后计算 MD5 或 SHA1
+func (s *Server) sign(signType string, params map[string]string) string {
+	var keys []string
+	for k := range params {
+		if k != "signature" && k != "tag" && k != "sms_signature" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+params[k])
+	}
+	finalStr := s.AppID + s.AppKey + strings.Join(parts, "&") + s.AppID + s.AppKey
+
+	if signType == submail.SignTypeSHA1 {
+		return fmt.Sprintf("%x", sha1.Sum([]byte(finalStr)))
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(finalStr)))
+}
+
+// ===== 工具类接口 =====
+
+func (s *Server) handleTimestamp(c *call) (interface{}, int) {
+	return map[string]interface{}{"timestamp": s.Now().Unix()}, 0
+}
+
+func (s *Server) handleStatus(c *call) (interface{}, int) {
+	return map[string]interface{}{"status": "runing", "runtime": 0.001}, 0
+}
+
+// ===== 短信发送 =====
+
+// outgoing 待发送的单条短信
+type outgoing struct {
+	to        string
+	content   string
+	project   string
+	vars      map[string]string
+	signature string
+}
+
+// deliver 发送一条短信，成功时记录短信并扣减余额，返回 send_id、计费条数与错误码
+func (s *Server) deliver(endpoint, tag string, m outgoing) (string, int, int) {
+	if m.to == "" {
+		return "", 0, submail.ErrIncorrectMessageAddress
+	}
+	if code := s.phoneFailures[m.to]; code != 0 {
+		return "", 0, code
+	}
+	if m.content == "" {
+		return "", 0, submail.ErrEmptyContent
+	}
+
+	fee := Fee(m.content)
+	if s.balance >= 0 {
+		if s.balance < fee {
+			return "", 0, submail.ErrInsufficientBalance
+		}
+		s.balance -= fee
+	}
+
+	sendID := s.newID("send")
+	s.messages = append(s.messages, Message{
+		SendID:    sendID,
+		Endpoint:  endpoint,
+		To:        m.to,
+		Content:   m.content,
+		Project:   m.project,
+		Vars:      m.vars,
+		Signature: m.signature,
+		Tag:       tag,
+		Fee:       fee,
+		SentAt:    s.Now(),
+	})
+	return sendID, fee, 0
+}
+
+// sendResult 单条发送结果
+func sendResult(to, sendID string, fee, code int) map[string]interface{} {
+	if code != 0 {
+		return map[string]interface{}{"status": "error", "to": to, "code": code, "msg": errorMessage(code)}
+	}
+	return map[string]interface{}{"status": "success", "to": to, "send_id": sendID, "fee": fee}
+}
+
+// single 单条发送的响应
+func (s *Server) single(c *call, m outgoing) (interface{}, int) {
+	sendID, fee, code := s.deliver(c.endpoint, c.params["tag"], m)
+	if code != 0 {
+		return nil, code
+	}
+	return map[string]interface{}{"status": "success", "send_id": sendID, "fee": fee}, 0
+}
+
+func (s *Server) handleSend(c *call) (interface{}, int) {
+	return s.single(c, outgoing{to: c.params["to"], content: c.params["content"]})
+}
+
+func (s *Server) handleUnionSend(c *call) (interface{}, int) {
+	content := c.params["content"]
+	if c.params["inter_content"] != "" && strings.HasPrefix(c.params["to"], "+") && !strings.HasPrefix(c.params["to"], "+86") {
+		content = c.params["inter_content"]
+	}
+	return s.single(c, outgoing{to: c.params["to"], content: content})
+}
+
+func (s *Server) handleXSend(c *call) (interface{}, int) {
+	vars, code := decodeVars(c.params["vars"])
+	if code != 0 {
+		return nil, code
+	}
+	m, code := s.renderTemplate(c.params["project"], c.params["sms_signature"], vars)
+	if code != 0 {
+		return nil, code
+	}
+	m.to = c.params["to"]
+	return s.single(c, m)
+}
+
+func (s *Server) handleMultiSend(c *call) (interface{}, int) {
+	var items []struct {
+		To   string            `json:"to"`
+		Vars map[string]string `json:"vars"`
+	}
+	if err := json.Unmarshal([]byte(c.params["multi"]), &items); err != nil || len(items) == 0 {
+		return nil, submail.ErrInvalidMultiParam
+	}
+	if c.params["content"] == "" {
+		return nil, submail.ErrEmptyContent
+	}
+
+	results := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		m := outgoing{to: item.To, content: render(c.params["content"], item.Vars), vars: item.Vars}
+		sendID, fee, code := s.deliver(c.endpoint, c.params["tag"], m)
+		results = append(results, sendResult(item.To, sendID, fee, code))
+	}
+	return results, 0
+}
+
+func (s *Server) handleMultiXSend(c *call) (interface{}, int) {
+	var items []struct {
+		To           string            `json:"to"`
+		Vars         map[string]string `json:"vars"`
+		SMSSignature string            `json:"sms_signature"`
+	}
+	if err := json.Unmarshal([]byte(c.params["multi"]), &items); err != nil || len(items) == 0 {
+		return nil, submail.ErrInvalidMultiParam
+	}
+	if _, code := s.renderTemplate(c.params["project"], "", nil); code != 0 {
+		return nil, code
+	}
+
+	results := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		signature := item.SMSSignature
+		if signature == "" {
+			signature = c.params["sms_signature"]
+		}
+		m, _ := s.renderTemplate(c.params["project"], signature, item.Vars)
+		m.to = item.To
+		sendID, fee, code := s.deliver(c.endpoint, c.params["tag"], m)
+		results = append(results, sendResult(item.To, sendID, fee, code))
+	}
+	return results, 0
+}
+
+// batch 批量发送到逗号分隔的多个号码
+func (s *Server) batch(c *call, m outgoing) (interface{}, int) {
+	var phones []string
+	for _, phone := range strings.Split(c.params["to"], ",") {
+		if phone = strings.TrimSpace(phone); phone != "" {
+			phones = append(phones, phone)
+		}
+	}
+	if len(phones) == 0 {
+		return nil, submail.ErrIncorrectMessageAddress
+	}
+
+	totalFee := 0
+	responses := make([]interface{}, 0, len(phones))
+	for _, phone := range phones {
+		m.to = phone
+		sendID, fee, code := s.deliver(c.endpoint, c.params["tag"], m)
+		totalFee += fee
+		responses = append(responses, sendResult(phone, sendID, fee, code))
+	}
+
+	return map[string]interface{}{
+		"status":    "success",
+		"batchlist": s.newID("batch"),
+		"total_fee": totalFee,
+		"responses": responses,
+	}, 0
+}
+
+func (s *Server) handleBatchSend(c *call) (interface{}, int) {
+	if c.params["content"] == "" {
+		return nil, submail.ErrEmptyContent
+	}
+	return s.batch(c, outgoing{content: c.params["content"]})
+}
+
+func (s *Server) handleBatchXSend(c *call) (interface{}, int) {
+	vars, code := decodeVars(c.params["vars"])
+	if code != 0 {
+		return nil, code
+	}
+	m, code := s.renderTemplate(c.params["project"], c.params["sms_signature"], vars)
+	if code != 0 {
+		return nil, code
+	}
+	return s.batch(c, m)
+}
+
+// renderTemplate 使用模板渲染短信正文，模板不存在或未审核通过时返回错误码
+func (s *Server) renderTemplate(project, signature string, vars map[string]string) (outgoing, int) {
+	if project == "" {
+		return outgoing{}, submail.ErrEmptyProjectIDForContent
+	}
+	t := s.findTemplate(project)
+	if t == nil {
+		return outgoing{}, submail.ErrInvalidProjectIDForContent
+	}
+	if t.TemplateStatus != "2" {
+		return outgoing{}, submail.ErrMessageUnderReview
+	}
+
+	content := render(t.SMSContent, vars)
+	if signature != "" && t.SMSSignature != "" {
+		content = strings.Replace(content, t.SMSSignature, signature, 1)
+	}
+	return outgoing{content: content, project: project, vars: vars, signature: signature}, 0
+}
+
+// render 替换正文中的 @var(key) 变量
+func render(content string, vars map[string]string) string {
+	return varPattern.ReplaceAllStringFunc(content, func(match string) string {
+		key := varPattern.FindStringSubmatch(match)[1]
+		if v, ok := vars[key]; ok {
+			return v
+		}
+		return match
+	})
+}
+
+// decodeVars 解析 JSON 格式的 vars 参数
+func decodeVars(raw string) (map[string]string, int) {
+	if raw == "" {
+		return nil, 0
+	}
+	var vars map[string]string
+	if err := json.Unmarshal([]byte(raw), &vars); err != nil {
+		return nil, submail.ErrIncorrectJSON
+	}
+	return vars, 0
+}
+
+// ===== 模板管理 =====
+
+func (s *Server) handleTemplate(c *call) (interface{}, int) {
+	switch c.method {
+	case http.MethodGet:
+		if id := c.params["template_id"]; id != "" {
+			t := s.findTemplate(id)
+			if t == nil {
+				return nil, submail.ErrTemplateNotExists
+			}
+			return map[string]interface{}{"status": "success", "template": *t}, 0
+		}
+		return map[string]interface{}{
+			"status":    "success",
+			"start_row": 0,
+			"end_row":   len(s.templates),
+			"templates": s.templates,
+		}, 0
+
+	case http.MethodPost:
+		if code := validateTemplate(c.params); code != 0 {
+			return nil, code
+		}
+		id := s.newID("tpl")
+		s.templates = append(s.templates, submail.SMSTemplate{
+			TemplateID:                id,
+			SMSTitle:                  c.params["sms_title"],
+			SMSSignature:              c.params["sms_signature"],
+			SMSContent:                c.params["sms_content"],
+			AddDate:                   s.Now().Unix(),
+			TemplateStatus:            "1",
+			TemplateStatusDescription: submail.GetTemplateStatus("1"),
+		})
+		return map[string]interface{}{"status": "success", "template_id": id}, 0
+
+	case http.MethodPut:
+		if c.params["template_id"] == "" {
+			return nil, submail.ErrEmptyTemplateID
+		}
+		t := s.findTemplate(c.params["template_id"])
+		if t == nil {
+			return nil, submail.ErrTemplateNotExists
+		}
+		if code := validateTemplate(c.params); code != 0 {
+			return nil, code
+		}
+		t.SMSTitle = c.params["sms_title"]
+		t.SMSSignature = c.params["sms_signature"]
+		t.SMSContent = c.params["sms_content"]
+		t.EditDate = s.Now().Unix()
+		t.TemplateStatus = "1"
+		t.TemplateStatusDescription = submail.GetTemplateStatus("1")
+		return map[string]interface{}{"status": "success"}, 0
+
+	case http.MethodDelete:
+		for i, t := range s.templates {
+			if t.TemplateID == c.params["template_id"] {
+				s.templates = append(s.templates[:i], s.templates[i+1:]...)
+				return map[string]interface{}{"status": "success"}, 0
+			}
+		}
+		return nil, submail.ErrTemplateNotExists
+	}
+	return nil, submail.ErrPermissionDenied
+}
+
+// validateTemplate 校验模板创建/更新参数
+func validateTemplate(params map[string]string) int {
+	switch {
+	case params["sms_signature"] == "":
+		return submail.ErrMissingSignatureInTemplate
+	case params["sms_content"] == "":
+		return submail.ErrEmptyContentInTemplate
+	case len([]rune(params["sms_content"])) > 1000:
+		return submail.ErrContentTooLongInTemplate
+	case len([]rune(params["sms_title"])) > 64:
+		return submail.ErrTitleTooLong
+	}
+	return 0
+}
+
+// findTemplate 按ID查找模板
+func (s *Server) findTemplate(id string) *submail.SMSTemplate {
+	for i := range s.templates {
+		if s.templates[i].TemplateID == id {
+			return &s.templates[i]
+		}
+	}
+	return nil
+}
+
+// ===== 查询类接口 =====
+
+func (s *Server) handleReports(c *call) (interface{}, int) {
+	fee := 0
+	for _, m := range s.messages {
+		fee += m.Fee
+	}
+	return map[string]interface{}{
+		"status": "success",
+		"overview": map[string]interface{}{
+			"request":    len(s.messages),
+			"deliveryed": len(s.messages),
+			"dropped":    0,
+			"fee":        fee,
+		},
+	}, 0
+}
+
+func (s *Server) handleBalance(c *call) (interface{}, int) {
+	balance := s.balance
+	if balance < 0 {
+		balance = 0
+	}
+	return map[string]interface{}{
+		"status":                "success",
+		"balance":               strconv.Itoa(balance),
+		"transactional_balance": "0",
+	}, 0
+}
+
+func (s *Server) handleBalanceLog(c *call) (interface{}, int) {
+	return map[string]interface{}{"status": "success", "data": []interface{}{}}, 0
+}
+
+func (s *Server) handleLog(c *call) (interface{}, int) {
+	data := []submail.SMSLog{}
+	for _, m := range s.messages {
+		if (c.params["to"] != "" && m.To != c.params["to"]) || (c.params["send_id"] != "" && m.SendID != c.params["send_id"]) {
+			continue
+		}
+		data = append(data, submail.SMSLog{
+			SendID:       m.SendID,
+			To:           m.To,
+			AppID:        s.AppID,
+			TemplateID:   m.Project,
+			SMSSignature: m.Signature,
+			SMSContent:   m.Content,
+			Fee:          m.Fee,
+			Status:       "delivered",
+			ReportState:  "DELIVRD",
+			SendAt:       m.SentAt.Unix(),
+			SentAt:       m.SentAt.Unix(),
+			ReportAt:     m.SentAt.Unix(),
+		})
+	}
+	return map[string]interface{}{"status": "success", "data": data}, 0
+}
+
+func (s *Server) handleMO(c *call) (interface{}, int) {
+	mo := []submail.SMSMO{}
+	for _, m := range s.mo {
+		if c.params["from"] == "" || m.From == c.params["from"] {
+			mo = append(mo, m)
+		}
+	}
+	return map[string]interface{}{"status": "success", "mo": mo}, 0
+}
+
+// ===== 签名管理 =====
+
+func (s *Server) handleAppextend(c *call) (interface{}, int) {
+	signature := c.params["sms_signature"]
+
+	switch {
+	case c.method == http.MethodGet:
+		list := []submail.SMSSignatureInfo{}
+		for _, info := range s.signatures {
+			if signature == "" || info.SMSSignature == signature {
+				list = append(list, info)
+			}
+		}
+		return map[string]interface{}{"status": "success", "smsSignature": list}, 0
+
+	case c.params["action"] == "delete":
+		for i, info := range s.signatures {
+			if info.SMSSignature == signature {
+				s.signatures = append(s.signatures[:i], s.signatures[i+1:]...)
+				return map[string]interface{}{"status": "success"}, 0
+			}
+		}
+		return nil, submail.ErrNoAvailableSignature
+
+	case c.method == http.MethodPost:
+		if signature == "" {
+			return nil, submail.ErrEmptyMessageSignature
+		}
+		for _, info := range s.signatures {
+			if info.SMSSignature == signature {
+				return nil, submail.ErrSignatureAlreadyExists
+			}
+		}
+		s.signatures = append(s.signatures, submail.SMSSignatureInfo{AppID: s.AppID, SMSSignature: signature})
+		return map[string]interface{}{"status": "success"}, 0
+
+	case c.method == http.MethodPut:
+		for i := range s.signatures {
+			if s.signatures[i].SMSSignature == signature {
+				s.signatures[i].Status = 0
+				return map[string]interface{}{"status": "success"}, 0
+			}
+		}
+		return nil, submail.ErrNoAvailableSignature
+	}
+	return nil, submail.ErrPermissionDenied
+}
+
+// ===== SUBHOOK =====
+
+func (s *Server) handleSubhook(c *call) (interface{}, int) {
+	target := c.params["target"]
+
+	switch c.method {
+	case http.MethodGet:
+		list := []submail.SubhookInfo{}
+		for _, info := range s.subhooks {
+			if target == "" || info.Target == target {
+				list = append(list, info)
+			}
+		}
+		return map[string]interface{}{"status": "success", "subhooks": list}, 0
+
+	case http.MethodPost:
+		var events []string
+		if err := json.Unmarshal([]byte(c.params["event"]), &events); err != nil {
+			return nil, submail.ErrIncorrectJSON
+		}
+		maxFails, _ := strconv.Atoi(c.params["max_fails"])
+		info := submail.SubhookInfo{
+			Target:     s.newID("hook"),
+			URL:        c.params["url"],
+			Event:      events,
+			Tag:        c.params["tag"],
+			MaxFails:   maxFails,
+			CreateTime: s.Now().Unix(),
+			Status:     "enabled",
+		}
+		s.subhooks = append(s.subhooks, info)
+		return map[string]interface{}{"status": "success", "target": info.Target, "key": s.newID("key")}, 0
+
+	case http.MethodDelete:
+		for i, info := range s.subhooks {
+			if info.Target == target {
+				s.subhooks = append(s.subhooks[:i], s.subhooks[i+1:]...)
+				return map[string]interface{}{"status": "success"}, 0
+			}
+		}
+		return nil, submail.ErrInvalidProjectID
+	}
+	return nil, submail.ErrPermissionDenied
+}
+
+// ===== 响应 =====
+
+// Fee 按模拟服务器的规则计算计费条数（与 submail.SegmentCount 一致，至少计1条）
+func Fee(content string) int {
+	if n := submail.SegmentCount(content, ""); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// newID 生成递增的唯一ID
+func (s *Server) newID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s%08d", prefix, s.seq)
+}
+
+// errorMessage 错误码对应的错误信息
+func errorMessage(code int) string {
+	if msg, ok := submail.ErrorMessages[code]; ok {
+		return msg
+	}
+	return "未知错误"
+}
+
+// errorResponse 错误响应
+func errorResponse(code int) map[string]interface{} {
+	return map[string]interface{}{"status": "error", "code": code, "msg": errorMessage(code)}
+}
+
+// writeResponse 按请求格式写入响应
+func writeResponse(w http.ResponseWriter, format string, v interface{}) {
+	if format == submail.FormatXML {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		var buf bytes.Buffer
+		buf.WriteString(xml.Header)
+		writeXML(&buf, "root", toGeneric(v))
+		w.Write(buf.Bytes())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+// toGeneric 通过 JSON 往返将结构体转换为 map/slice，以便按 json 标签输出 XML
+func toGeneric(v interface{}) interface{} {
+	data, _ := json.Marshal(v)
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.Decode(&generic)
+	return generic
+}
+
+// writeXML 写入XML元素：对象按键名排序输出子元素，数组输出为包含 <item> 的容器（空数组省略）
+func writeXML(buf *bytes.Buffer, name string, v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteString("<" + name + ">")
+		for _, k := range keys {
+			if list, ok := val[k].([]interface{}); ok && len(list) == 0 {
+				continue
+			}
+			writeXML(buf, k, val[k])
+		}
+		buf.WriteString("</" + name + ">")
+	case []interface{}:
+		buf.WriteString("<" + name + ">")
+		for _, item := range val {
+			writeXML(buf, "item", item)
+		}
+		buf.WriteString("</" + name + ">")
+	case nil:
+		buf.WriteString("<" + name + "></" + name + ">")
+	default:
+		buf.WriteString("<" + name + ">")
+		xml.EscapeText(buf, []byte(fmt.Sprint(val)))
+		buf.WriteString("</" + name + ">")
+	}
+}
@@ -0,0 +1,52 @@
+package submailtest
+
+import (
+	"fmt"
+
+	"github.com/zhoudm1743/submail"
+)
+
+// 消息键
+const (
+	msgCassetteMode    = "cassette_mode"
+	msgCassetteRead    = "cassette_read"
+	msgCassetteParse   = "cassette_parse"
+	msgCassetteParams  = "cassette_params"
+	msgCassetteMarshal = "cassette_marshal"
+	msgCassetteDir     = "cassette_dir"
+	msgCassetteWrite   = "cassette_write"
+	msgCassetteNoMatch = "cassette_no_match"
+)
+
+// messageCatalog 测试工具消息目录（按语言区分），语言跟随 submail.DefaultLocale
+var messageCatalog = map[string]map[string]string{
+	submail.LocaleZhCN: {
+		msgCassetteMode:    "不支持的录制回放模式: %s",
+		msgCassetteRead:    "读取录制文件失败: %w",
+		msgCassetteParse:   "解析录制文件失败: %w",
+		msgCassetteParams:  "解析请求参数失败: %w",
+		msgCassetteMarshal: "序列化录制文件失败: %w",
+		msgCassetteDir:     "创建录制目录失败: %w",
+		msgCassetteWrite:   "写入录制文件失败: %w",
+		msgCassetteNoMatch: "录制文件中没有匹配的请求: %s %s %s",
+	},
+	submail.LocaleEN: {
+		msgCassetteMode:    "unsupported cassette mode: %s",
+		msgCassetteRead:    "failed to read cassette file: %w",
+		msgCassetteParse:   "failed to parse cassette file: %w",
+		msgCassetteParams:  "failed to parse request parameters: %w",
+		msgCassetteMarshal: "failed to marshal cassette file: %w",
+		msgCassetteDir:     "failed to create cassette directory: %w",
+		msgCassetteWrite:   "failed to write cassette file: %w",
+		msgCassetteNoMatch: "no recorded request matches: %s %s %s",
+	},
+}
+
+// localizedError 创建默认语言的错误（消息中的 %w 会保留错误链）
+func localizedError(key string, args ...interface{}) error {
+	msg, ok := messageCatalog[submail.DefaultLocale()][key]
+	if !ok {
+		msg = messageCatalog[submail.LocaleZhCN][key]
+	}
+	return fmt.Errorf(msg, args...)
+}
@@ -0,0 +1,553 @@
+package submailtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+)
+
+// Call 记录的一次调用
+type Call struct {
+	Method  string      // 方法名（不含 Ctx 后缀），如 SMSSend
+	Request interface{} // 请求参数，如 *submail.SMSSendRequest（SMSBalance 为 nil）
+	Time    time.Time   // 调用时间
+}
+
+// result 预设的返回值
+type result struct {
+	resp interface{}
+	err  error
+}
+
+// Recorder 内存中的记录实现，实现了 submail 包中的所有接口，用于业务代码的单元测试
+// 记录每次调用的请求参数；未预设返回值时返回默认的成功响应
+//
+//	recorder := submailtest.NewRecorder()
+//	recorder.ReturnNext("SMSSend", nil, submail.NewAPIError(submail.ErrQuotaExhausted, ""))
+//
+//	svc := NewNotifyService(recorder) // 业务代码依赖 submail.SMSSender
+//	svc.Notify("13800138000")
+//
+//	recorder.CallsOf("SMSSend")[0].Request.(*submail.SMSSendRequest).To
+type Recorder struct {
+	mu     sync.Mutex
+	calls  []Call
+	next   map[string][]result
+	always map[string]result
+	seq    int
+}
+
+// 编译期检查 *Recorder 实现了所有接口
+var (
+	_ submail.SMSSender        = (*Recorder)(nil)
+	_ submail.TemplateManager  = (*Recorder)(nil)
+	_ submail.SignatureManager = (*Recorder)(nil)
+	_ submail.LogQuerier       = (*Recorder)(nil)
+	_ submail.SubhookManager   = (*Recorder)(nil)
+)
+
+// NewRecorder 创建记录实现
+func NewRecorder() *Recorder {
+	return &Recorder{
+		next:   make(map[string][]result),
+		always: make(map[string]result),
+	}
+}
+
+// Return 设置方法之后每次调用的返回值
+// method 为不含 Ctx 后缀的方法名；resp 必须是该方法的响应类型（如 *submail.SMSSendResponse），
+// 返回错误时可为 nil
+func (r *Recorder) Return(method string, resp interface{}, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.always[method] = result{resp: resp, err: err}
+}
+
+// ReturnNext 设置方法下一次调用的返回值（可多次调用排队），优先于 Return
+func (r *Recorder) ReturnNext(method string, resp interface{}, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next[method] = append(r.next[method], result{resp: resp, err: err})
+}
+
+// Calls 获取所有调用记录
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsOf 获取指定方法的调用记录
+func (r *Recorder) CallsOf(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// LastCall 获取最后一次调用记录
+func (r *Recorder) LastCall() (Call, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.calls) == 0 {
+		return Call{}, false
+	}
+	return r.calls[len(r.calls)-1], true
+}
+
+// Reset 清空调用记录与预设的返回值
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+	r.next = make(map[string][]result)
+	r.always = make(map[string]result)
+	r.seq = 0
+}
+
+// record 记录调用并取出预设的返回值，ok 为 false 时使用默认响应
+func (r *Recorder) record(ctx context.Context, method string, req interface{}) (resp interface{}, ok bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Request: req, Time: time.Now()})
+
+	if err := ctx.Err(); err != nil {
+		return nil, true, err
+	}
+	if queue := r.next[method]; len(queue) > 0 {
+		r.next[method] = queue[1:]
+		return queue[0].resp, true, queue[0].err
+	}
+	if res, found := r.always[method]; found {
+		return res.resp, true, res.err
+	}
+	return nil, false, nil
+}
+
+// newID 生成递增的唯一ID
+func (r *Recorder) newID(prefix string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	return fmt.Sprintf("%s%08d", prefix, r.seq)
+}
+
+// success 成功的基础响应
+func success() submail.BaseResponse {
+	return submail.BaseResponse{Status: "success"}
+}
+
+// sendResponse 默认的单条发送响应
+func (r *Recorder) sendResponse() *submail.SMSSendResponse {
+	return &submail.SMSSendResponse{BaseResponse: success(), SendID: r.newID("send"), Fee: 1, Sms: 1}
+}
+
+// multiResponse 默认的一对多发送响应
+func (r *Recorder) multiResponse(phones []string) *submail.SMSMultiSendResponse {
+	resp := make(submail.SMSMultiSendResponse, 0, len(phones))
+	for _, phone := range phones {
+		resp = append(resp, submail.SMSSendResult{Status: "success", To: phone, SendID: r.newID("send"), Fee: 1})
+	}
+	return &resp
+}
+
+// batchResponse 默认的批量发送响应
+func (r *Recorder) batchResponse(to string) *submail.SMSBatchSendResponse {
+	resp := &submail.SMSBatchSendResponse{BaseResponse: success(), BatchList: r.newID("batch")}
+	for _, phone := range strings.Split(to, ",") {
+		if phone = strings.TrimSpace(phone); phone == "" {
+			continue
+		}
+		resp.Responses = append(resp.Responses, submail.SMSSendResult{Status: "success", To: phone, SendID: r.newID("send"), Fee: 1})
+		resp.TotalFee++
+	}
+	return resp
+}
+
+// ===== 短信发送 =====
+
+// SMSSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSSend(req *submail.SMSSendRequest) (*submail.SMSSendResponse, error) {
+	return r.SMSSendCtx(context.Background(), req)
+}
+
+// SMSSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSSendCtx(ctx context.Context, req *submail.SMSSendRequest) (*submail.SMSSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSSend", req)
+	if !ok {
+		return r.sendResponse(), nil
+	}
+	out, _ := resp.(*submail.SMSSendResponse)
+	return out, err
+}
+
+// SMSXSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSXSend(req *submail.SMSXSendRequest) (*submail.SMSSendResponse, error) {
+	return r.SMSXSendCtx(context.Background(), req)
+}
+
+// SMSXSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSXSendCtx(ctx context.Context, req *submail.SMSXSendRequest) (*submail.SMSSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSXSend", req)
+	if !ok {
+		return r.sendResponse(), nil
+	}
+	out, _ := resp.(*submail.SMSSendResponse)
+	return out, err
+}
+
+// SMSMultiSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSMultiSend(req *submail.SMSMultiSendRequest) (*submail.SMSMultiSendResponse, error) {
+	return r.SMSMultiSendCtx(context.Background(), req)
+}
+
+// SMSMultiSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSMultiSendCtx(ctx context.Context, req *submail.SMSMultiSendRequest) (*submail.SMSMultiSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSMultiSend", req)
+	if !ok {
+		var phones []string
+		if req != nil {
+			for _, item := range req.Multi {
+				phones = append(phones, item.To)
+			}
+		}
+		return r.multiResponse(phones), nil
+	}
+	out, _ := resp.(*submail.SMSMultiSendResponse)
+	return out, err
+}
+
+// SMSMultiXSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSMultiXSend(req *submail.SMSMultiXSendRequest) (*submail.SMSMultiSendResponse, error) {
+	return r.SMSMultiXSendCtx(context.Background(), req)
+}
+
+// SMSMultiXSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSMultiXSendCtx(ctx context.Context, req *submail.SMSMultiXSendRequest) (*submail.SMSMultiSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSMultiXSend", req)
+	if !ok {
+		var phones []string
+		if req != nil {
+			for _, item := range req.Multi {
+				phones = append(phones, item.To)
+			}
+		}
+		return r.multiResponse(phones), nil
+	}
+	out, _ := resp.(*submail.SMSMultiSendResponse)
+	return out, err
+}
+
+// SMSBatchSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSBatchSend(req *submail.SMSBatchSendRequest) (*submail.SMSBatchSendResponse, error) {
+	return r.SMSBatchSendCtx(context.Background(), req)
+}
+
+// SMSBatchSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSBatchSendCtx(ctx context.Context, req *submail.SMSBatchSendRequest) (*submail.SMSBatchSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSBatchSend", req)
+	if !ok {
+		var to string
+		if req != nil {
+			to = req.To
+		}
+		return r.batchResponse(to), nil
+	}
+	out, _ := resp.(*submail.SMSBatchSendResponse)
+	return out, err
+}
+
+// SMSBatchXSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSBatchXSend(req *submail.SMSBatchXSendRequest) (*submail.SMSBatchSendResponse, error) {
+	return r.SMSBatchXSendCtx(context.Background(), req)
+}
+
+// SMSBatchXSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSBatchXSendCtx(ctx context.Context, req *submail.SMSBatchXSendRequest) (*submail.SMSBatchSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSBatchXSend", req)
+	if !ok {
+		var to string
+		if req != nil {
+			to = req.To
+		}
+		return r.batchResponse(to), nil
+	}
+	out, _ := resp.(*submail.SMSBatchSendResponse)
+	return out, err
+}
+
+// SMSUnionSend 实现 submail.SMSSender 接口
+func (r *Recorder) SMSUnionSend(req *submail.SMSUnionSendRequest) (*submail.SMSSendResponse, error) {
+	return r.SMSUnionSendCtx(context.Background(), req)
+}
+
+// SMSUnionSendCtx 实现 submail.SMSSender 接口
+func (r *Recorder) SMSUnionSendCtx(ctx context.Context, req *submail.SMSUnionSendRequest) (*submail.SMSSendResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSUnionSend", req)
+	if !ok {
+		return r.sendResponse(), nil
+	}
+	out, _ := resp.(*submail.SMSSendResponse)
+	return out, err
+}
+
+// ===== 模板管理 =====
+
+// SMSTemplateGet 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateGet(req *submail.SMSTemplateGetRequest) (*submail.SMSTemplateGetResponse, error) {
+	return r.SMSTemplateGetCtx(context.Background(), req)
+}
+
+// SMSTemplateGetCtx 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateGetCtx(ctx context.Context, req *submail.SMSTemplateGetRequest) (*submail.SMSTemplateGetResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSTemplateGet", req)
+	if !ok {
+		return &submail.SMSTemplateGetResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSTemplateGetResponse)
+	return out, err
+}
+
+// SMSTemplateCreate 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateCreate(req *submail.SMSTemplateCreateRequest) (*submail.SMSTemplateCreateResponse, error) {
+	return r.SMSTemplateCreateCtx(context.Background(), req)
+}
+
+// SMSTemplateCreateCtx 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateCreateCtx(ctx context.Context, req *submail.SMSTemplateCreateRequest) (*submail.SMSTemplateCreateResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSTemplateCreate", req)
+	if !ok {
+		return &submail.SMSTemplateCreateResponse{BaseResponse: success(), TemplateID: r.newID("tpl")}, nil
+	}
+	out, _ := resp.(*submail.SMSTemplateCreateResponse)
+	return out, err
+}
+
+// SMSTemplateUpdate 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateUpdate(req *submail.SMSTemplateUpdateRequest) (*submail.SMSTemplateOperationResponse, error) {
+	return r.SMSTemplateUpdateCtx(context.Background(), req)
+}
+
+// SMSTemplateUpdateCtx 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateUpdateCtx(ctx context.Context, req *submail.SMSTemplateUpdateRequest) (*submail.SMSTemplateOperationResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSTemplateUpdate", req)
+	if !ok {
+		return &submail.SMSTemplateOperationResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSTemplateOperationResponse)
+	return out, err
+}
+
+// SMSTemplateDelete 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateDelete(req *submail.SMSTemplateDeleteRequest) (*submail.SMSTemplateOperationResponse, error) {
+	return r.SMSTemplateDeleteCtx(context.Background(), req)
+}
+
+// SMSTemplateDeleteCtx 实现 submail.TemplateManager 接口
+func (r *Recorder) SMSTemplateDeleteCtx(ctx context.Context, req *submail.SMSTemplateDeleteRequest) (*submail.SMSTemplateOperationResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSTemplateDelete", req)
+	if !ok {
+		return &submail.SMSTemplateOperationResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSTemplateOperationResponse)
+	return out, err
+}
+
+// ===== 签名管理 =====
+
+// SMSSignatureQuery 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureQuery(req *submail.SMSSignatureQueryRequest) (*submail.SMSSignatureQueryResponse, error) {
+	return r.SMSSignatureQueryCtx(context.Background(), req)
+}
+
+// SMSSignatureQueryCtx 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureQueryCtx(ctx context.Context, req *submail.SMSSignatureQueryRequest) (*submail.SMSSignatureQueryResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSSignatureQuery", req)
+	if !ok {
+		return &submail.SMSSignatureQueryResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSSignatureQueryResponse)
+	return out, err
+}
+
+// SMSSignatureCreate 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureCreate(req *submail.SMSSignatureCreateRequest) (*submail.SMSSignatureOperationResponse, error) {
+	return r.SMSSignatureCreateCtx(context.Background(), req)
+}
+
+// SMSSignatureCreateCtx 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureCreateCtx(ctx context.Context, req *submail.SMSSignatureCreateRequest) (*submail.SMSSignatureOperationResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSSignatureCreate", req)
+	if !ok {
+		return &submail.SMSSignatureOperationResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSSignatureOperationResponse)
+	return out, err
+}
+
+// SMSSignatureUpdate 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureUpdate(req *submail.SMSSignatureUpdateRequest) (*submail.SMSSignatureOperationResponse, error) {
+	return r.SMSSignatureUpdateCtx(context.Background(), req)
+}
+
+// SMSSignatureUpdateCtx 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureUpdateCtx(ctx context.Context, req *submail.SMSSignatureUpdateRequest) (*submail.SMSSignatureOperationResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSSignatureUpdate", req)
+	if !ok {
+		return &submail.SMSSignatureOperationResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSSignatureOperationResponse)
+	return out, err
+}
+
+// SMSSignatureDelete 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureDelete(req *submail.SMSSignatureDeleteRequest) (*submail.SMSSignatureOperationResponse, error) {
+	return r.SMSSignatureDeleteCtx(context.Background(), req)
+}
+
+// SMSSignatureDeleteCtx 实现 submail.SignatureManager 接口
+func (r *Recorder) SMSSignatureDeleteCtx(ctx context.Context, req *submail.SMSSignatureDeleteRequest) (*submail.SMSSignatureOperationResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSSignatureDelete", req)
+	if !ok {
+		return &submail.SMSSignatureOperationResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSSignatureOperationResponse)
+	return out, err
+}
+
+// ===== 查询 =====
+
+// SMSLog 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSLog(req *submail.SMSLogRequest) (*submail.SMSLogResponse, error) {
+	return r.SMSLogCtx(context.Background(), req)
+}
+
+// SMSLogCtx 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSLogCtx(ctx context.Context, req *submail.SMSLogRequest) (*submail.SMSLogResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSLog", req)
+	if !ok {
+		return &submail.SMSLogResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSLogResponse)
+	return out, err
+}
+
+// SMSMO 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSMO(req *submail.SMSMORequest) (*submail.SMSMOResponse, error) {
+	return r.SMSMOCtx(context.Background(), req)
+}
+
+// SMSMOCtx 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSMOCtx(ctx context.Context, req *submail.SMSMORequest) (*submail.SMSMOResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSMO", req)
+	if !ok {
+		return &submail.SMSMOResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSMOResponse)
+	return out, err
+}
+
+// SMSReports 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSReports(req *submail.SMSReportsRequest) (*submail.SMSReportsResponse, error) {
+	return r.SMSReportsCtx(context.Background(), req)
+}
+
+// SMSReportsCtx 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSReportsCtx(ctx context.Context, req *submail.SMSReportsRequest) (*submail.SMSReportsResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSReports", req)
+	if !ok {
+		return &submail.SMSReportsResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSReportsResponse)
+	return out, err
+}
+
+// SMSBalance 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSBalance() (*submail.SMSBalanceResponse, error) {
+	return r.SMSBalanceCtx(context.Background())
+}
+
+// SMSBalanceCtx 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSBalanceCtx(ctx context.Context) (*submail.SMSBalanceResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSBalance", nil)
+	if !ok {
+		return &submail.SMSBalanceResponse{BaseResponse: success(), Balance: "0", TransactionalBalance: "0"}, nil
+	}
+	out, _ := resp.(*submail.SMSBalanceResponse)
+	return out, err
+}
+
+// SMSBalanceLog 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSBalanceLog(req *submail.SMSBalanceLogRequest) (*submail.SMSBalanceLogResponse, error) {
+	return r.SMSBalanceLogCtx(context.Background(), req)
+}
+
+// SMSBalanceLogCtx 实现 submail.LogQuerier 接口
+func (r *Recorder) SMSBalanceLogCtx(ctx context.Context, req *submail.SMSBalanceLogRequest) (*submail.SMSBalanceLogResponse, error) {
+	resp, ok, err := r.record(ctx, "SMSBalanceLog", req)
+	if !ok {
+		return &submail.SMSBalanceLogResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SMSBalanceLogResponse)
+	return out, err
+}
+
+// ===== SUBHOOK =====
+
+// SubhookCreate 实现 submail.SubhookManager 接口
+func (r *Recorder) SubhookCreate(req *submail.SubhookCreateRequest) (*submail.SubhookCreateResponse, error) {
+	return r.SubhookCreateCtx(context.Background(), req)
+}
+
+// SubhookCreateCtx 实现 submail.SubhookManager 接口
+func (r *Recorder) SubhookCreateCtx(ctx context.Context, req *submail.SubhookCreateRequest) (*submail.SubhookCreateResponse, error) {
+	resp, ok, err := r.record(ctx, "SubhookCreate", req)
+	if !ok {
+		return &submail.SubhookCreateResponse{BaseResponse: success(), Target: r.newID("hook"), Key: r.newID("key")}, nil
+	}
+	out, _ := resp.(*submail.SubhookCreateResponse)
+	return out, err
+}
+
+// SubhookQuery 实现 submail.SubhookManager 接口
+func (r *Recorder) SubhookQuery(req *submail.SubhookQueryRequest) (*submail.SubhookQueryResponse, error) {
+	return r.SubhookQueryCtx(context.Background(), req)
+}
+
+// SubhookQueryCtx 实现 submail.SubhookManager 接口
+func (r *Recorder) SubhookQueryCtx(ctx context.Context, req *submail.SubhookQueryRequest) (*submail.SubhookQueryResponse, error) {
+	resp, ok, err := r.record(ctx, "SubhookQuery", req)
+	if !ok {
+		return &submail.SubhookQueryResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SubhookQueryResponse)
+	return out, err
+}
+
+// SubhookDelete 实现 submail.SubhookManager 接口
+func (r *Recorder) SubhookDelete(req *submail.SubhookDeleteRequest) (*submail.SubhookDeleteResponse, error) {
+	return r.SubhookDeleteCtx(context.Background(), req)
+}
+
+// SubhookDeleteCtx 实现 submail.SubhookManager 接口
+func (r *Recorder) SubhookDeleteCtx(ctx context.Context, req *submail.SubhookDeleteRequest) (*submail.SubhookDeleteResponse, error) {
+	resp, ok, err := r.record(ctx, "SubhookDelete", req)
+	if !ok {
+		return &submail.SubhookDeleteResponse{BaseResponse: success()}, nil
+	}
+	out, _ := resp.(*submail.SubhookDeleteResponse)
+	return out, err
+}
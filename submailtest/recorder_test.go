@@ -0,0 +1,84 @@
+package submailtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+// 编译期检查 *submail.Client 实现了所有接口
+var (
+	_ submail.SMSSender        = (*submail.Client)(nil)
+	_ submail.TemplateManager  = (*submail.Client)(nil)
+	_ submail.SignatureManager = (*submail.Client)(nil)
+	_ submail.LogQuerier       = (*submail.Client)(nil)
+	_ submail.SubhookManager   = (*submail.Client)(nil)
+)
+
+// notify 依赖 submail.SMSSender 的业务代码
+func notify(sender submail.SMSSender, phones ...string) error {
+	for _, phone := range phones {
+		if _, err := sender.SMSSend(&submail.SMSSendRequest{To: phone, Content: "【测试】您的订单已发货"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRecorderDefaultResponses(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+
+	resp, err := recorder.SMSSend(&submail.SMSSendRequest{To: "13800138000"})
+	if err != nil || resp.Status != "success" || resp.SendID == "" {
+		t.Fatalf("SMSSend = %+v, %v", resp, err)
+	}
+	batch, err := recorder.SMSBatchSend(&submail.SMSBatchSendRequest{To: "13800138000, 13800138001"})
+	if err != nil || len(batch.Responses) != 2 || batch.TotalFee != 2 {
+		t.Fatalf("SMSBatchSend = %+v, %v", batch, err)
+	}
+}
+
+func TestRecorderScriptedResults(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	quota := submail.NewAPIError(submail.ErrQuotaExhausted, "")
+	disabled := submail.NewAPIError(submail.ErrAppDisabled, "")
+	recorder.Return("SMSSend", nil, disabled)
+	recorder.ReturnNext("SMSSend", nil, quota)
+
+	if err := notify(recorder, "13800138000"); !errors.Is(err, quota) {
+		t.Fatalf("first call err = %v, want queued 901", err)
+	}
+	if err := notify(recorder, "13800138001"); !errors.Is(err, disabled) {
+		t.Fatalf("second call err = %v, want 102", err)
+	}
+
+	calls := recorder.CallsOf("SMSSend")
+	if len(calls) != 2 || calls[1].Request.(*submail.SMSSendRequest).To != "13800138001" {
+		t.Fatalf("calls = %+v", calls)
+	}
+
+	recorder.Reset()
+	if len(recorder.Calls()) != 0 {
+		t.Fatal("Reset did not clear calls")
+	}
+	if err := notify(recorder, "13800138000"); err != nil {
+		t.Fatalf("after Reset: %v", err)
+	}
+}
+
+func TestRecorderHonorsContext(t *testing.T) {
+	recorder := submailtest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := recorder.SMSBalanceCtx(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	call, ok := recorder.LastCall()
+	if !ok || call.Method != "SMSBalance" {
+		t.Fatalf("LastCall = %+v, %v", call, ok)
+	}
+}
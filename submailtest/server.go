@@ -0,0 +1,295 @@
+// Package submailtest 提供用于集成测试的本地 SUBMAIL 模拟服务器
+//
+// 模拟服务器基于 httptest 实现了 service.go 中使用的全部接口，按照与客户端 buildSignature
+// 完全相同的规则校验明文/MD5/SHA1 签名，记录所有请求与已发送的短信以便断言，
+// 并可以按接口或手机号脚本化返回 errors.go 中的任意错误码。
+//
+//	server := submailtest.NewServer("test-app", "test-key")
+//	defer server.Close()
+//
+//	client := submail.NewClient(server.Config())
+//	client.SMSSend(&submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"})
+//
+//	server.Messages() // 已发送的短信
+package submailtest
+
+import (
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+)
+
+// DefaultTimestampTolerance 数字签名时间戳允许的默认偏差
+const DefaultTimestampTolerance = 6 * time.Second
+
+// Message 模拟服务器收到的短信
+type Message struct {
+	SendID    string            // 发送ID
+	Endpoint  string            // 发送接口，如 /sms/send
+	To        string            // 收件人手机号码
+	Content   string            // 短信正文（模板发送时为渲染后的内容）
+	Project   string            // 模板ID（模板发送时）
+	Vars      map[string]string // 变量
+	Signature string            // 自定义短信签名
+	Tag       string            // 自定义标签
+	Fee       int               // 计费条数
+	SentAt    time.Time         // 发送时间
+}
+
+// Request 模拟服务器收到的请求
+type Request struct {
+	Method   string            // HTTP方法
+	Endpoint string            // API端点（不含 .json/.xml 后缀）
+	Format   string            // 响应格式：json 或 xml
+	Params   map[string]string // 请求参数（包含认证参数）
+	Files    int               // 上传的文件数量（multipart 请求）
+}
+
+// failure 脚本化的错误
+type failure struct {
+	code   int // API错误码
+	status int // HTTP状态码（非0时返回HTTP错误）
+}
+
+// Server SUBMAIL 模拟服务器
+type Server struct {
+	*httptest.Server
+
+	AppID  string // 应用ID
+	AppKey string // 应用密钥
+
+	// TimestampTolerance 数字签名时间戳允许的偏差（默认6秒，负数表示不校验）
+	TimestampTolerance time.Duration
+
+	// Now 模拟服务器的当前时间（默认 time.Now），需在发起请求前设置
+	Now func() time.Time
+
+	mu            sync.Mutex
+	requests      []Request
+	messages      []Message
+	templates     []submail.SMSTemplate
+	signatures    []submail.SMSSignatureInfo
+	subhooks      []submail.SubhookInfo
+	mo            []submail.SMSMO
+	balance       int // 余额（条），负数表示不限
+	next          map[string][]failure
+	always        map[string]failure
+	phoneFailures map[string]int
+	seq           int
+}
+
+// NewServer 创建并启动模拟服务器
+func NewServer(appID, appKey string) *Server {
+	s := &Server{
+		AppID:              appID,
+		AppKey:             appKey,
+		TimestampTolerance: DefaultTimestampTolerance,
+		Now:                time.Now,
+	}
+	s.reset()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Config 返回连接到模拟服务器的客户端配置（主API与日志API均指向模拟服务器）
+func (s *Server) Config() submail.Config {
+	return submail.Config{
+		AppID:       s.AppID,
+		AppKey:      s.AppKey,
+		BaseURL:     s.URL,
+		LogBaseURLs: []string{s.URL},
+	}
+}
+
+// Reset 清空所有记录、数据与脚本化错误
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reset()
+}
+
+func (s *Server) reset() {
+	s.requests = nil
+	s.messages = nil
+	s.templates = nil
+	s.signatures = nil
+	s.subhooks = nil
+	s.mo = nil
+	s.balance = -1
+	s.next = make(map[string][]failure)
+	s.always = make(map[string]failure)
+	s.phoneFailures = make(map[string]int)
+	s.seq = 0
+}
+
+// ===== 脚本化错误 =====
+
+// FailNext 让指定接口的下一次请求返回API错误码（可多次调用排队）
+func (s *Server) FailNext(endpoint string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next[endpoint] = append(s.next[endpoint], failure{code: code})
+}
+
+// FailNextHTTP 让指定接口的下一次请求返回HTTP错误状态码
+func (s *Server) FailNextHTTP(endpoint string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next[endpoint] = append(s.next[endpoint], failure{status: status})
+}
+
+// FailAlways 让指定接口的所有请求返回API错误码，直到调用 ClearFailures
+func (s *Server) FailAlways(endpoint string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.always[endpoint] = failure{code: code}
+}
+
+// FailPhone 发送到指定手机号时返回API错误码
+// 单条发送时整个请求返回错误；一对多及批量发送时仅该号码的结果为错误
+func (s *Server) FailPhone(phone string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phoneFailures[phone] = code
+}
+
+// ClearFailures 清除所有脚本化错误
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = make(map[string][]failure)
+	s.always = make(map[string]failure)
+	s.phoneFailures = make(map[string]int)
+}
+
+// takeFailure 取出接口对应的脚本化错误
+func (s *Server) takeFailure(endpoint string) (failure, bool) {
+	if queue := s.next[endpoint]; len(queue) > 0 {
+		s.next[endpoint] = queue[1:]
+		return queue[0], true
+	}
+	f, ok := s.always[endpoint]
+	return f, ok
+}
+
+// ===== 数据准备 =====
+
+// SetBalance 设置短信余额（条），余额不足时发送返回 904 错误；负数表示不限（默认）
+func (s *Server) SetBalance(balance int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balance = balance
+}
+
+// Balance 获取当前短信余额
+func (s *Server) Balance() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.balance
+}
+
+// AddTemplate 添加短信模板，TemplateID 为空时自动生成，TemplateStatus 为空时视为审核通过
+// 返回模板ID
+func (s *Server) AddTemplate(template submail.SMSTemplate) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if template.TemplateID == "" {
+		template.TemplateID = s.newID("tpl")
+	}
+	if template.TemplateStatus == "" {
+		template.TemplateStatus = "2"
+	}
+	if template.AddDate == 0 {
+		template.AddDate = s.Now().Unix()
+	}
+	template.TemplateStatusDescription = submail.GetTemplateStatus(template.TemplateStatus)
+	s.templates = append(s.templates, template)
+	return template.TemplateID
+}
+
+// SetTemplateStatus 设置模板审核状态（0=未提交、1=审核中、2=通过、3=未通过）
+func (s *Server) SetTemplateStatus(templateID, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t := s.findTemplate(templateID); t != nil {
+		t.TemplateStatus = status
+		t.TemplateStatusDescription = submail.GetTemplateStatus(status)
+	}
+}
+
+// AddMO 添加上行短信（用户回复）
+func (s *Server) AddMO(mo submail.SMSMO) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if mo.AppID == "" {
+		mo.AppID = s.AppID
+	}
+	if mo.ReplyAt == 0 {
+		mo.ReplyAt = s.Now().Unix()
+	}
+	s.mo = append(s.mo, mo)
+}
+
+// ===== 断言 =====
+
+// Requests 获取收到的所有请求
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Messages 获取已成功发送的所有短信
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// MessagesTo 获取发送到指定手机号的短信
+func (s *Server) MessagesTo(phone string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var messages []Message
+	for _, m := range s.messages {
+		if m.To == phone {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// LastMessage 获取最后一条已发送的短信
+func (s *Server) LastMessage() (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.messages) == 0 {
+		return Message{}, false
+	}
+	return s.messages[len(s.messages)-1], true
+}
+
+// Subhooks 获取已创建的 SUBHOOK
+func (s *Server) Subhooks() []submail.SubhookInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]submail.SubhookInfo(nil), s.subhooks...)
+}
+
+// Templates 获取所有模板
+func (s *Server) Templates() []submail.SMSTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	templates := append([]submail.SMSTemplate(nil), s.templates...)
+	sort.Slice(templates, func(i, j int) bool { return templates[i].AddDate < templates[j].AddDate })
+	return templates
+}
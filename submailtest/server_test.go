@@ -0,0 +1,132 @@
+package submailtest_test
+
+import (
+	"testing"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+func TestServerAuthentication(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+
+	for _, signType := range []string{"", submail.SignTypeMD5, submail.SignTypeSHA1} {
+		config := server.Config()
+		config.UseDigitalSign = signType != ""
+		config.SignType = signType
+		if _, err := submail.NewClient(config).SMSBalance(); err != nil {
+			t.Fatalf("sign type %q: %v", signType, err)
+		}
+	}
+
+	config := server.Config()
+	config.AppKey = "wrong-key"
+	if _, err := submail.NewClient(config).SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrInvalidAppKey) {
+		t.Fatalf("plaintext with wrong key: err = %v, want 109", err)
+	}
+	config.UseDigitalSign = true
+	if _, err := submail.NewClient(config).SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrInvalidSignature) {
+		t.Fatalf("digital sign with wrong key: err = %v, want 108", err)
+	}
+	config = server.Config()
+	config.AppID = "other-app"
+	if _, err := submail.NewClient(config).SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrIncorrectAppID) {
+		t.Fatalf("wrong app id: err = %v, want 101", err)
+	}
+}
+
+func TestServerScriptedFailures(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	server.FailNext(submail.EndpointSMSBalance, submail.ErrQuotaExhausted)
+	server.FailNext(submail.EndpointSMSBalance, submail.ErrAppDisabled)
+	if _, err := client.SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrQuotaExhausted) {
+		t.Fatalf("first: err = %v, want 901", err)
+	}
+	if _, err := client.SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrAppDisabled) {
+		t.Fatalf("second: err = %v, want 102", err)
+	}
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("third: %v", err)
+	}
+
+	server.FailAlways(submail.EndpointSMSBalance, submail.ErrIPNotInWhitelist)
+	for i := 0; i < 2; i++ {
+		if _, err := client.SMSBalance(); !submail.IsAPIErrorCode(err, submail.ErrIPNotInWhitelist) {
+			t.Fatalf("always #%d: err = %v, want 113", i, err)
+		}
+	}
+	server.ClearFailures()
+	if _, err := client.SMSBalance(); err != nil {
+		t.Fatalf("after ClearFailures: %v", err)
+	}
+}
+
+func TestServerPhoneFailureInMultiSend(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	server.FailPhone("13800138001", submail.ErrPhoneInBlacklist)
+	resp, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】您好",
+		Multi:   []submail.SMSMultiItem{{To: "13800138000"}, {To: "13800138001"}},
+	})
+	if err != nil {
+		t.Fatalf("SMSMultiSend: %v", err)
+	}
+	success, failed, _ := resp.GetStatistics()
+	if success != 1 || failed != 1 || (*resp)[1].Code != submail.ErrPhoneInBlacklist {
+		t.Fatalf("unexpected results: %+v", *resp)
+	}
+	if len(server.MessagesTo("13800138001")) != 0 || len(server.MessagesTo("13800138000")) != 1 {
+		t.Fatalf("messages = %+v", server.Messages())
+	}
+}
+
+func TestServerBalance(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	server.SetBalance(1)
+	req := &submail.SMSSendRequest{To: "13800138000", Content: "【测试】验证码1234"}
+	if _, err := client.SMSSend(req); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	if server.Balance() != 0 {
+		t.Fatalf("balance = %d, want 0", server.Balance())
+	}
+	if _, err := client.SMSSend(req); !submail.IsQuotaError(err) {
+		t.Fatalf("err = %v, want quota error", err)
+	}
+}
+
+func TestServerTemplates(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client := submail.NewClient(server.Config())
+
+	id := server.AddTemplate(submail.SMSTemplate{SMSSignature: "【测试】", SMSContent: "【测试】您的验证码是@var(code)"})
+	if _, err := client.SMSXSend(&submail.SMSXSendRequest{To: "13800138000", Project: id, Vars: map[string]string{"code": "1234"}}); err != nil {
+		t.Fatalf("SMSXSend: %v", err)
+	}
+	msg, ok := server.LastMessage()
+	if !ok || msg.Content != "【测试】您的验证码是1234" || msg.Project != id {
+		t.Fatalf("last message = %+v", msg)
+	}
+
+	server.SetTemplateStatus(id, "1")
+	_, err := client.SMSXSend(&submail.SMSXSendRequest{To: "13800138000", Project: id})
+	if !submail.IsAPIErrorCode(err, submail.ErrMessageUnderReview) {
+		t.Fatalf("err = %v, want 409", err)
+	}
+
+	server.Reset()
+	if len(server.Messages()) != 0 || len(server.Templates()) != 0 || len(server.Requests()) != 0 {
+		t.Fatal("Reset did not clear the server")
+	}
+}
@@ -0,0 +1,520 @@
+package submail
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhoudm1743/submail/phone"
+)
+
+// SendStatusSkipped 收件人在屏蔽名单中，未发送
+const SendStatusSkipped = "skipped"
+
+// 屏蔽原因
+const (
+	SuppressionUnsubscribed = "unsubscribed" // 已退订（上行回复 TD/退订 等，或 253 错误）
+	SuppressionBlacklisted  = "blacklisted"  // 在账户黑名单中（114 错误）
+	SuppressionManual       = "manual"       // 手动添加
+)
+
+// 屏蔽来源
+const (
+	SuppressionSourceMO      = "mo"      // 上行短信查询（SMSMO）
+	SuppressionSourceSubhook = "subhook" // SUBHOOK 上行事件
+	SuppressionSourceAPI     = "api"     // 发送接口返回的错误
+	SuppressionSourceManual  = "manual"  // 手动添加或导入
+)
+
+// ErrSuppressed 收件人在屏蔽名单中，可使用 errors.Is 判断
+var ErrSuppressed error = sentinelError(msgErrSuppressed)
+
+// SuppressedError 单条发送的收件人在屏蔽名单中（请求未发出）
+type SuppressedError struct {
+	Phone  string // 手机号码
+	Reason string // 屏蔽原因
+
+	locale string // 错误信息语言
+}
+
+func (e *SuppressedError) Error() string {
+	return localizef(e.locale, msgSuppressed, e.Phone, e.Reason)
+}
+
+// Is 支持 errors.Is(err, ErrSuppressed)
+func (e *SuppressedError) Is(target error) bool {
+	return target == ErrSuppressed
+}
+
+// SuppressionEntry 屏蔽名单记录
+type SuppressionEntry struct {
+	Phone     string    // 手机号码（E.164 格式）
+	Reason    string    // 屏蔽原因
+	Source    string    // 来源
+	Detail    string    // 详情（如上行内容、错误信息）
+	CreatedAt time.Time // 加入时间
+}
+
+// SuppressionStore 屏蔽名单存储
+// 多实例部署时可基于 Redis、数据库等共享存储实现
+type SuppressionStore interface {
+	// Add 添加记录，已存在的号码保持原记录不变，返回新增的数量
+	Add(ctx context.Context, entries []*SuppressionEntry) (int, error)
+	// Remove 移除号码，返回移除的数量
+	Remove(ctx context.Context, phones []string) (int, error)
+	// Lookup 查询号码，返回在名单中的号码及其记录
+	Lookup(ctx context.Context, phones []string) (map[string]*SuppressionEntry, error)
+	// List 列出所有记录
+	List(ctx context.Context) ([]*SuppressionEntry, error)
+}
+
+// SuppressionList 屏蔽名单：配置到 Config.Suppression 后，所有发送接口在请求前过滤名单中的收件人，
+// 并自动加入返回 114（黑名单）、253（已退订）错误的号码
+//
+//	suppression := submail.NewSuppressionList(nil)
+//	client := submail.NewClient(submail.Config{AppID: "...", AppKey: "...", Suppression: suppression})
+//	go suppression.PollMO(ctx, client, time.Minute) // 定期同步上行退订
+type SuppressionList struct {
+	store SuppressionStore
+	now   func() time.Time
+}
+
+// NewSuppressionList 创建屏蔽名单，store 为 nil 时使用进程内存储
+func NewSuppressionList(store SuppressionStore) *SuppressionList {
+	if store == nil {
+		store = NewMemorySuppressionStore()
+	}
+	return &SuppressionList{store: store, now: time.Now}
+}
+
+// Add 手动添加号码（reason 为空时为 SuppressionManual），已在名单中的号码保持原记录不变
+func (l *SuppressionList) Add(ctx context.Context, phoneNumber, reason, detail string) error {
+	if reason == "" {
+		reason = SuppressionManual
+	}
+	_, err := l.add(ctx, phoneNumber, reason, SuppressionSourceManual, detail)
+	return err
+}
+
+// Remove 从名单中移除号码
+func (l *SuppressionList) Remove(ctx context.Context, phoneNumber string) error {
+	_, err := l.store.Remove(ctx, []string{normalizeSuppressed(phoneNumber)})
+	return err
+}
+
+// Contains 号码是否在名单中
+func (l *SuppressionList) Contains(ctx context.Context, phoneNumber string) (bool, error) {
+	entries, err := l.Lookup(ctx, []string{phoneNumber})
+	if err != nil {
+		return false, err
+	}
+	return len(entries) > 0, nil
+}
+
+// Lookup 批量查询号码，返回在名单中的号码（使用传入的写法作为键）及其记录
+func (l *SuppressionList) Lookup(ctx context.Context, phones []string) (map[string]*SuppressionEntry, error) {
+	normalized := make([]string, len(phones))
+	for i, p := range phones {
+		normalized[i] = normalizeSuppressed(p)
+	}
+	found, err := l.store.Lookup(ctx, normalized)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+
+	entries := make(map[string]*SuppressionEntry, len(found))
+	for i, p := range phones {
+		if entry, ok := found[normalized[i]]; ok {
+			entries[p] = entry
+		}
+	}
+	return entries, nil
+}
+
+// List 列出所有记录（按加入时间排序）
+func (l *SuppressionList) List(ctx context.Context) ([]*SuppressionEntry, error) {
+	entries, err := l.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].Phone < entries[j].Phone
+	})
+	return entries, nil
+}
+
+// Export 以 CSV 格式导出所有记录（列：phone,reason,source,detail,created_at）
+func (l *SuppressionList) Export(ctx context.Context, w io.Writer) error {
+	entries, err := l.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"phone", "reason", "source", "detail", "created_at"})
+	for _, entry := range entries {
+		writer.Write([]string{entry.Phone, entry.Reason, entry.Source, entry.Detail, entry.CreatedAt.Format(time.RFC3339)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Import 导入 Export 导出的 CSV（也可以只有 phone 一列），返回新增的数量
+func (l *SuppressionList) Import(ctx context.Context, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := l.now()
+	var entries []*SuppressionEntry
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && record[0] == "phone" {
+			continue
+		}
+		field := func(n int) string {
+			if n < len(record) {
+				return strings.TrimSpace(record[n])
+			}
+			return ""
+		}
+		if field(0) == "" {
+			continue
+		}
+
+		entry := &SuppressionEntry{
+			Phone:     normalizeSuppressed(field(0)),
+			Reason:    field(1),
+			Source:    field(2),
+			Detail:    field(3),
+			CreatedAt: now,
+		}
+		if entry.Reason == "" {
+			entry.Reason = SuppressionManual
+		}
+		if entry.Source == "" {
+			entry.Source = SuppressionSourceManual
+		}
+		if t, err := time.Parse(time.RFC3339, field(4)); err == nil {
+			entry.CreatedAt = t
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	return l.store.Add(ctx, entries)
+}
+
+// ===== 自动加入 =====
+
+// ApplyMO 将上行短信中的退订回复（SMSMO.IsReturnReceipt）加入名单，返回新增的数量
+func (l *SuppressionList) ApplyMO(ctx context.Context, mos []SMSMO) (int, error) {
+	var entries []*SuppressionEntry
+	for _, mo := range mos {
+		if mo.IsReturnReceipt() {
+			entries = append(entries, l.entry(mo.From, SuppressionUnsubscribed, SuppressionSourceMO, mo.Content))
+		}
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	return l.store.Add(ctx, entries)
+}
+
+// SyncMO 查询时间范围内的所有上行短信（自动翻页）并将退订号码加入名单，返回新增的数量
+func (l *SuppressionList) SyncMO(ctx context.Context, querier LogQuerier, startDate, endDate time.Time) (int, error) {
+	const rows = 100
+
+	added := 0
+	for offset := 0; ; {
+		resp, err := querier.SMSMOCtx(ctx, &SMSMORequest{
+			StartDate: startDate.Unix(),
+			EndDate:   endDate.Unix(),
+			Rows:      rows,
+			Offset:    offset,
+		})
+		if err != nil {
+			return added, err
+		}
+
+		n, err := l.ApplyMO(ctx, resp.MO)
+		added += n
+		if err != nil {
+			return added, err
+		}
+
+		offset += len(resp.MO)
+		if len(resp.MO) < rows || (resp.Total > 0 && offset >= resp.Total) {
+			return added, nil
+		}
+	}
+}
+
+// PollMO 每隔 interval（默认1分钟）同步一次上行退订，直到 ctx 取消
+// 每次查询上次查询之后的上行短信，查询失败时下次从同一时间继续
+func (l *SuppressionList) PollMO(ctx context.Context, querier LogQuerier, interval time.Duration) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	since := l.now().Add(-interval)
+	for {
+		until := l.now()
+		if _, err := l.SyncMO(ctx, querier, since, until); err == nil {
+			// 接口按秒查询，重叠1秒以免遗漏（重复的号码不会重复加入）
+			since = until.Add(-time.Second)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandleMO 处理 SUBHOOK 上行事件，退订回复加入名单，可直接用作 DefaultSubhookEventHandler.OnMO
+func (l *SuppressionList) HandleMO(eventData *SubhookEventData, mo *SMSMOSubhookEventData) error {
+	if !(&SMSMO{From: mo.From, Content: mo.Content}).IsReturnReceipt() {
+		return nil
+	}
+	_, err := l.add(context.Background(), mo.From, SuppressionUnsubscribed, SuppressionSourceSubhook, mo.Content)
+	return err
+}
+
+// add 添加单个号码
+func (l *SuppressionList) add(ctx context.Context, phoneNumber, reason, source, detail string) (int, error) {
+	return l.store.Add(ctx, []*SuppressionEntry{l.entry(phoneNumber, reason, source, detail)})
+}
+
+// entry 创建记录
+func (l *SuppressionList) entry(phoneNumber, reason, source, detail string) *SuppressionEntry {
+	return &SuppressionEntry{
+		Phone:     normalizeSuppressed(phoneNumber),
+		Reason:    reason,
+		Source:    source,
+		Detail:    detail,
+		CreatedAt: l.now(),
+	}
+}
+
+// normalizeSuppressed 号码统一为 E.164 格式，无法解析时使用去除空白后的原值
+func normalizeSuppressed(phoneNumber string) string {
+	if normalized, err := phone.Normalize(phoneNumber); err == nil {
+		return normalized
+	}
+	return strings.TrimSpace(phoneNumber)
+}
+
+// suppressionReason 错误码对应的屏蔽原因
+func suppressionReason(code int) (string, bool) {
+	switch code {
+	case ErrPhoneInBlacklist:
+		return SuppressionBlacklisted, true
+	case ErrContactUnsubscribed:
+		return SuppressionUnsubscribed, true
+	}
+	return "", false
+}
+
+// ===== 发送接口集成 =====
+
+// Suppression 获取客户端配置的屏蔽名单（未配置时为nil）
+func (c *Client) Suppression() *SuppressionList {
+	return c.suppression
+}
+
+// suppressed 查询屏蔽名单，返回被屏蔽的收件人位置及对应的跳过结果（未配置屏蔽名单时返回nil）
+func (c *Client) suppressed(ctx context.Context, recipients []string) (map[int]SMSSendResult, error) {
+	if c.suppression == nil || len(recipients) == 0 {
+		return nil, nil
+	}
+	entries, err := c.suppression.Lookup(ctx, recipients)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	skipped := make(map[int]SMSSendResult, len(entries))
+	for i, to := range recipients {
+		if entry, ok := entries[to]; ok {
+			skipped[i] = c.skippedResult(to, entry)
+		}
+	}
+	return skipped, nil
+}
+
+// suppressSingle 单条发送的屏蔽检查，收件人被屏蔽时返回 *SuppressedError（不返回响应）
+func (c *Client) suppressSingle(ctx context.Context, to string) error {
+	if c.suppression == nil {
+		return nil
+	}
+	entries, err := c.suppression.Lookup(ctx, []string{to})
+	if err != nil {
+		return err
+	}
+	entry, ok := entries[to]
+	if !ok {
+		return nil
+	}
+	return &SuppressedError{Phone: to, Reason: entry.Reason, locale: c.locale}
+}
+
+// skippedResult 被屏蔽收件人的跳过结果，Code 为屏蔽原因对应的错误码（114 或 253，手动添加时为0）
+func (c *Client) skippedResult(to string, entry *SuppressionEntry) SMSSendResult {
+	result := SMSSendResult{Status: SendStatusSkipped, To: to, Msg: localizef(c.locale, msgSuppressed, to, entry.Reason)}
+	switch entry.Reason {
+	case SuppressionBlacklisted:
+		result.Code = ErrPhoneInBlacklist
+	case SuppressionUnsubscribed:
+		result.Code = ErrContactUnsubscribed
+	}
+	return result
+}
+
+// learnSuppression 将发送结果中返回 114、253 错误的号码加入屏蔽名单（存储失败时写入日志，不影响发送结果）
+func (c *Client) learnSuppression(ctx context.Context, results []SMSSendResult) {
+	if c.suppression == nil {
+		return
+	}
+	var entries []*SuppressionEntry
+	for _, result := range results {
+		if reason, ok := suppressionReason(result.Code); ok && result.To != "" {
+			entries = append(entries, c.suppression.entry(result.To, reason, SuppressionSourceAPI, result.Msg))
+		}
+	}
+	if len(entries) > 0 {
+		if _, err := c.suppression.store.Add(context.WithoutCancel(ctx), entries); err != nil {
+			c.logf(msgSuppressionLearn, len(entries), err)
+		}
+	}
+}
+
+// learnSuppressionError 单条发送返回 114、253 错误时将收件人加入屏蔽名单
+func (c *Client) learnSuppressionError(ctx context.Context, to string, err error) {
+	if apiErr, ok := AsAPIError(err); ok {
+		c.learnSuppression(ctx, []SMSSendResult{{Status: "error", To: to, Code: apiErr.Code, Msg: apiErr.Msg}})
+	}
+}
+
+// withoutSkipped 去除被屏蔽的收件人
+func withoutSkipped[T any](items []T, skipped map[int]SMSSendResult) []T {
+	kept := make([]T, 0, len(items)-len(skipped))
+	for i, item := range items {
+		if _, ok := skipped[i]; !ok {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// mergeSkipped 按收件人原顺序合并发送结果与跳过结果
+// 发送结果数量与实际发送的收件人数不一致时，跳过结果追加在末尾
+func mergeSkipped(results []SMSSendResult, skipped map[int]SMSSendResult, total int) []SMSSendResult {
+	if len(skipped) == 0 {
+		return results
+	}
+
+	merged := make([]SMSSendResult, 0, len(results)+len(skipped))
+	if len(results) != total-len(skipped) {
+		merged = append(merged, results...)
+		for i := 0; i < total; i++ {
+			if result, ok := skipped[i]; ok {
+				merged = append(merged, result)
+			}
+		}
+		return merged
+	}
+
+	next := 0
+	for i := 0; i < total; i++ {
+		if result, ok := skipped[i]; ok {
+			merged = append(merged, result)
+			continue
+		}
+		merged = append(merged, results[next])
+		next++
+	}
+	return merged
+}
+
+// ===== 进程内屏蔽名单存储 =====
+
+// MemorySuppressionStore 进程内屏蔽名单存储（并发安全），可通过 Export / Import 持久化
+type MemorySuppressionStore struct {
+	mu      sync.RWMutex
+	entries map[string]*SuppressionEntry
+}
+
+// NewMemorySuppressionStore 创建进程内屏蔽名单存储
+func NewMemorySuppressionStore() *MemorySuppressionStore {
+	return &MemorySuppressionStore{entries: make(map[string]*SuppressionEntry)}
+}
+
+// Add 实现 SuppressionStore 接口
+func (s *MemorySuppressionStore) Add(ctx context.Context, entries []*SuppressionEntry) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	added := 0
+	for _, entry := range entries {
+		if _, ok := s.entries[entry.Phone]; ok {
+			continue
+		}
+		e := *entry
+		s.entries[entry.Phone] = &e
+		added++
+	}
+	return added, nil
+}
+
+// Remove 实现 SuppressionStore 接口
+func (s *MemorySuppressionStore) Remove(ctx context.Context, phones []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for _, p := range phones {
+		if _, ok := s.entries[p]; ok {
+			delete(s.entries, p)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// Lookup 实现 SuppressionStore 接口
+func (s *MemorySuppressionStore) Lookup(ctx context.Context, phones []string) (map[string]*SuppressionEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	found := make(map[string]*SuppressionEntry)
+	for _, p := range phones {
+		if entry, ok := s.entries[p]; ok {
+			e := *entry
+			found[p] = &e
+		}
+	}
+	return found, nil
+}
+
+// List 实现 SuppressionStore 接口
+func (s *MemorySuppressionStore) List(ctx context.Context) ([]*SuppressionEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]*SuppressionEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		e := *entry
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,205 @@
+package submail_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+	"github.com/zhoudm1743/submail/submailtest"
+)
+
+// suppressedClient 创建配置了屏蔽名单的客户端，names 中的号码预先加入名单
+func suppressedClient(server *submailtest.Server, names ...string) (*submail.Client, *submail.SuppressionList) {
+	suppression := submail.NewSuppressionList(nil)
+	for _, p := range names {
+		suppression.Add(context.Background(), p, "", "")
+	}
+	config := server.Config()
+	config.Suppression = suppression
+	return submail.NewClient(config), suppression
+}
+
+func TestSuppressionApplyMO(t *testing.T) {
+	suppression := submail.NewSuppressionList(nil)
+	ctx := context.Background()
+
+	added, err := suppression.ApplyMO(ctx, []submail.SMSMO{
+		{From: "13800138000", Content: " TD "},
+		{From: "13800138001", Content: "退订"},
+		{From: "13800138002", Content: "收到，谢谢"},
+		{From: "+86 138 0013 8000", Content: "T"},
+	})
+	if err != nil || added != 2 {
+		t.Fatalf("ApplyMO = %d, %v, want 2 new entries", added, err)
+	}
+	if ok, _ := suppression.Contains(ctx, "+8613800138001"); !ok {
+		t.Fatal("unsubscribed number not in list")
+	}
+	if ok, _ := suppression.Contains(ctx, "13800138002"); ok {
+		t.Fatal("normal reply added to list")
+	}
+
+	entries, _ := suppression.List(ctx)
+	if entries[0].Reason != submail.SuppressionUnsubscribed || entries[0].Source != submail.SuppressionSourceMO {
+		t.Fatalf("entry = %+v", entries[0])
+	}
+}
+
+func TestSuppressionSyncMO(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client, suppression := suppressedClient(server)
+
+	now := time.Now()
+	server.AddMO(submail.SMSMO{From: "13800138000", Content: "TD", ReplyAt: now.Unix()})
+	server.AddMO(submail.SMSMO{From: "13800138001", Content: "好的", ReplyAt: now.Unix()})
+
+	added, err := suppression.SyncMO(context.Background(), client, now.Add(-time.Hour), now.Add(time.Hour))
+	if err != nil || added != 1 {
+		t.Fatalf("SyncMO = %d, %v, want 1", added, err)
+	}
+}
+
+func TestSuppressionSkipsSingleSend(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client, _ := suppressedClient(server, "13800138000")
+
+	resp, err := client.SMSSend(&submail.SMSSendRequest{To: "+86 13800138000", Content: "【测试】您好"})
+	var suppressed *submail.SuppressedError
+	if !errors.As(err, &suppressed) || !errors.Is(err, submail.ErrSuppressed) || suppressed.Reason != submail.SuppressionManual {
+		t.Fatalf("err = %v, want *SuppressedError", err)
+	}
+	if resp != nil {
+		t.Fatalf("resp = %+v, want nil", resp)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("server received %d requests, want 0", n)
+	}
+}
+
+func TestSuppressionSkipsMultiAndBatch(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client, _ := suppressedClient(server, "13800138001")
+
+	multi, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】您好",
+		Multi:   []submail.SMSMultiItem{{To: "13800138000"}, {To: "13800138001"}, {To: "13800138002"}},
+	})
+	if err != nil {
+		t.Fatalf("SMSMultiSend: %v", err)
+	}
+	statuses := []string{"success", submail.SendStatusSkipped, "success"}
+	for i, r := range *multi {
+		if r.Status != statuses[i] {
+			t.Errorf("multi result %d = %+v, want %s", i, r, statuses[i])
+		}
+	}
+
+	batch, err := client.SMSBatchSendWithPhones("【测试】您好", []string{"13800138001", "13800138002"}, "")
+	if err != nil {
+		t.Fatalf("SMSBatchSendWithPhones: %v", err)
+	}
+	if len(batch.Responses) != 2 || batch.Responses[0].Status != submail.SendStatusSkipped || batch.Responses[1].Status != "success" {
+		t.Fatalf("batch results = %+v", batch.Responses)
+	}
+	if n := len(server.MessagesTo("13800138001")); n != 0 {
+		t.Fatalf("suppressed number received %d messages", n)
+	}
+}
+
+func TestSuppressionLearnsFromErrors(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	client, suppression := suppressedClient(server)
+	server.FailPhone("13800138001", submail.ErrContactUnsubscribed)
+	server.FailPhone("13800138002", submail.ErrPhoneInBlacklist)
+	server.FailPhone("13800138003", submail.ErrPhoneFrequencyLimit)
+
+	client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】您好",
+		Multi:   []submail.SMSMultiItem{{To: "13800138000"}, {To: "13800138001"}, {To: "13800138003"}},
+	})
+	client.SMSSend(&submail.SMSSendRequest{To: "13800138002", Content: "【测试】您好"})
+
+	ctx := context.Background()
+	entries, _ := suppression.Lookup(ctx, []string{"13800138000", "13800138001", "13800138002", "13800138003"})
+	if len(entries) != 2 {
+		t.Fatalf("learned %d numbers, want 2: %v", len(entries), entries)
+	}
+	if e := entries["13800138001"]; e == nil || e.Reason != submail.SuppressionUnsubscribed || e.Source != submail.SuppressionSourceAPI {
+		t.Fatalf("253 entry = %+v", e)
+	}
+	if e := entries["13800138002"]; e == nil || e.Reason != submail.SuppressionBlacklisted {
+		t.Fatalf("114 entry = %+v", e)
+	}
+}
+
+// failingSuppressionStore 添加记录总是失败的屏蔽名单存储
+type failingSuppressionStore struct {
+	submail.SuppressionStore
+}
+
+func (failingSuppressionStore) Add(context.Context, []*submail.SuppressionEntry) (int, error) {
+	return 0, errors.New("store unavailable")
+}
+
+func TestSuppressionLearnLogsStoreErrors(t *testing.T) {
+	server := submailtest.NewServer("test-app", "test-key")
+	defer server.Close()
+	server.FailPhone("13800138001", submail.ErrContactUnsubscribed)
+
+	var buf bytes.Buffer
+	config := server.Config()
+	config.Suppression = submail.NewSuppressionList(failingSuppressionStore{submail.NewMemorySuppressionStore()})
+	config.Logger = log.New(&buf, "", 0)
+	client := submail.NewClient(config)
+
+	if _, err := client.SMSMultiSend(&submail.SMSMultiSendRequest{
+		Content: "【测试】您好",
+		Multi:   []submail.SMSMultiItem{{To: "13800138000"}, {To: "13800138001"}},
+	}); err != nil {
+		t.Fatalf("SMSMultiSend: %v", err)
+	}
+	if !strings.Contains(buf.String(), "store unavailable") {
+		t.Fatalf("log = %q, want store error", buf.String())
+	}
+}
+
+func TestSuppressionExportImport(t *testing.T) {
+	ctx := context.Background()
+	source := submail.NewSuppressionList(nil)
+	source.Add(ctx, "13800138000", submail.SuppressionBlacklisted, "客户投诉")
+	source.Add(ctx, "13800138001", "", "")
+
+	var buf bytes.Buffer
+	if err := source.Export(ctx, &buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	target := submail.NewSuppressionList(nil)
+	if n, err := target.Import(ctx, &buf); err != nil || n != 2 {
+		t.Fatalf("Import = %d, %v, want 2", n, err)
+	}
+	entries, _ := target.Lookup(ctx, []string{"13800138000"})
+	if e := entries["13800138000"]; e == nil || e.Reason != submail.SuppressionBlacklisted || e.Detail != "客户投诉" {
+		t.Fatalf("imported entry = %+v", e)
+	}
+
+	// 只有号码一列，已存在的号码不重复加入
+	if n, err := target.Import(ctx, strings.NewReader("13800138001\n13800138002\n")); err != nil || n != 1 {
+		t.Fatalf("Import phone column = %d, %v, want 1", n, err)
+	}
+	if err := target.Remove(ctx, "+8613800138002"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if ok, _ := target.Contains(ctx, "13800138002"); ok {
+		t.Fatal("removed number still in list")
+	}
+}
@@ -0,0 +1,57 @@
+package submail
+
+import (
+	"net/http"
+)
+
+// newHTTPClient 根据配置创建HTTP客户端
+// 优先使用 Config.HTTPClient；否则使用 Config.Transport 或按代理、TLS、连接池选项构建的传输层
+func newHTTPClient(config Config) *http.Client {
+	if config.HTTPClient != nil {
+		return config.HTTPClient
+	}
+
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: newTransport(config),
+	}
+}
+
+// newTransport 根据配置创建传输层，未设置任何传输选项时返回nil（使用 http.DefaultTransport）
+func newTransport(config Config) http.RoundTripper {
+	if config.Transport != nil {
+		return config.Transport
+	}
+
+	if config.ProxyURL == nil && config.TLSConfig == nil && config.MaxIdleConns == 0 &&
+		config.MaxIdleConnsPerHost == 0 && config.MaxConnsPerHost == 0 && config.IdleConnTimeout == 0 {
+		return nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(config.ProxyURL)
+	}
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig.Clone()
+	}
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+
+	return transport
+}
+
+// HTTPClient 获取客户端使用的HTTP客户端
+func (c *Client) HTTPClient() *http.Client {
+	return c.client
+}
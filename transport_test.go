@@ -0,0 +1,81 @@
+package submail_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhoudm1743/submail"
+)
+
+// roundTripperFunc 函数形式的传输层
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestConfigTransportIsUsed(t *testing.T) {
+	var calls int
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"status":"success","balance":"100","transactional_balance":"50"}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", Transport: transport})
+	resp, err := client.SMSBalance()
+	if err != nil {
+		t.Fatalf("SMSBalance: %v", err)
+	}
+	if calls != 1 || resp.Balance != "100" {
+		t.Fatalf("calls = %d, balance = %q", calls, resp.Balance)
+	}
+}
+
+func TestConfigHTTPClientIsUsedAsIs(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Second}
+	client := submail.NewClient(submail.Config{AppID: "test-app", AppKey: "test-key", HTTPClient: httpClient, Timeout: time.Minute})
+	if client.HTTPClient() != httpClient {
+		t.Fatal("HTTPClient() did not return the configured client")
+	}
+}
+
+func TestNewTransportOptions(t *testing.T) {
+	if transport := submail.NewClient(submail.Config{}).HTTPClient().Transport; transport != nil {
+		t.Fatalf("transport without options = %T, want nil", transport)
+	}
+
+	proxy, _ := url.Parse("http://proxy.example.com:8080")
+	tlsConfig := &tls.Config{ServerName: "api.example.com"}
+	transport, ok := submail.NewClient(submail.Config{
+		ProxyURL:            proxy,
+		TLSConfig:           tlsConfig,
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		MaxConnsPerHost:     20,
+		IdleConnTimeout:     time.Minute,
+	}).HTTPClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("client transport is not *http.Transport")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.mysubmail.com", nil)
+	if got, _ := transport.Proxy(req); got == nil || got.String() != proxy.String() {
+		t.Fatalf("proxy = %v, want %v", got, proxy)
+	}
+	if transport.TLSClientConfig == tlsConfig || transport.TLSClientConfig.ServerName != "api.example.com" {
+		t.Fatal("TLS config was not cloned")
+	}
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.MaxConnsPerHost != 20 || transport.IdleConnTimeout != time.Minute {
+		t.Fatalf("pool options not applied: %+v", transport)
+	}
+}
@@ -4,33 +4,48 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 // VariableProcessor 变量处理器
 type VariableProcessor struct {
-	timezone *time.Location // 时区设置
+	timezone atomic.Pointer[time.Location] // 时区设置（定时发送在后台读取，可与 SetTimezone 并发）
+	locale   string                        // 错误信息语言
 }
 
 // NewVariableProcessor 创建变量处理器
 func NewVariableProcessor() *VariableProcessor {
 	// 默认使用中国时区
 	location, _ := time.LoadLocation("Asia/Shanghai")
-	return &VariableProcessor{
-		timezone: location,
-	}
+	vp := &VariableProcessor{locale: LocaleZhCN}
+	vp.timezone.Store(location)
+	return vp
+}
+
+// SetLocale 设置错误信息语言
+func (vp *VariableProcessor) SetLocale(locale string) {
+	vp.locale = normalizeLocale(locale)
 }
 
 // SetTimezone 设置时区
 func (vp *VariableProcessor) SetTimezone(timezone string) error {
 	location, err := time.LoadLocation(timezone)
 	if err != nil {
-		return fmt.Errorf("无效的时区: %v", err)
+		return localizedError(vp.locale, msgInvalidTimezone, err)
 	}
-	vp.timezone = location
+	vp.timezone.Store(location)
 	return nil
 }
 
+// Timezone 获取时区
+func (vp *VariableProcessor) Timezone() *time.Location {
+	if location := vp.timezone.Load(); location != nil {
+		return location
+	}
+	return time.Local
+}
+
 // ProcessVariables 处理短信内容中的变量
 func (vp *VariableProcessor) ProcessVariables(content string, vars map[string]string) string {
 	// 处理自定义变量 @var(key_name)
@@ -70,7 +85,7 @@ func (vp *VariableProcessor) processCustomVariables(content string, vars map[str
 
 // processDateVariables 处理日期时间变量
 func (vp *VariableProcessor) processDateVariables(content string) string {
-	now := time.Now().In(vp.timezone)
+	now := time.Now().In(vp.Timezone())
 
 	// 定义日期变量映射
 	dateVars := map[string]string{
@@ -107,7 +122,7 @@ func (vp *VariableProcessor) ValidateVariables(content string) []string {
 
 	for _, match := range varMatches {
 		if !regexp.MustCompile(`^@var\([a-zA-Z_][a-zA-Z0-9_]*\)$`).MatchString(match) {
-			errors = append(errors, fmt.Sprintf("无效的变量格式: %s", match))
+			errors = append(errors, localizef(vp.locale, msgInvalidVariable, match))
 		}
 	}
 
@@ -124,7 +139,7 @@ func (vp *VariableProcessor) ValidateVariables(content string) []string {
 
 	for _, match := range dateMatches {
 		if !validDateFormats[match] {
-			errors = append(errors, fmt.Sprintf("无效的日期变量格式: %s", match))
+			errors = append(errors, localizef(vp.locale, msgInvalidDateVariable, match))
 		}
 	}
 